@@ -0,0 +1,358 @@
+package gobuild
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/chronos"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+)
+
+// TinyStack is the identifier for the Tiny stack, which does not ship the
+// binaries (e.g. a shell) that watchexec depends on.
+const TinyStack = "io.paketo.stacks.tiny"
+
+// Build executes the compile-time logic for the Go build buildpack, compiling
+// the application source into one or more binaries, generating an SBOM for
+// them, and assigning a launch process for each one.
+func Build(
+	parser ConfigurationParser,
+	buildProcess BuildProcess,
+	checksumCalculator ChecksumCalculator,
+	pathManager PathManager,
+	clock chronos.Clock,
+	logger scribe.Emitter,
+	sourceRemover SourceRemover,
+	sbomGenerator SBOMGenerator,
+	moduleSBOMGenerator ModuleSBOMGenerator,
+	liveReloadConfigurer LiveReloadConfigurer,
+	provenanceGenerator ProvenanceGenerator,
+	goVersionResolver GoVersionResolver,
+) packit.BuildFunc {
+	return func(context packit.BuildContext) (packit.BuildResult, error) {
+		logger.Title("%s %s", context.BuildpackInfo.Name, context.BuildpackInfo.Version)
+
+		liveReloadEnabled, err := checkLiveReloadEnabled()
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
+		if liveReloadEnabled {
+			if _, _, err := liveReloadConfigurer.Configure(context.Stack, context.WorkingDir, ""); err != nil {
+				return packit.BuildResult{}, err
+			}
+		}
+
+		provenanceEnabled, err := checkProvenanceEnabled()
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
+		config, err := parser.Parse(context.WorkingDir, context.BuildpackInfo.Version)
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
+		targetsLayer, err := context.Layers.Get("targets")
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
+		gocacheLayer, err := context.Layers.Get("gocache")
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
+		// The module SBOM is carried on its own layer, rather than appended to
+		// targetsLayer.SBOM, because packit writes each layer's SBOM.Formats() to
+		// "<layer>.sbom.<extension>": two formatters sharing an extension (e.g.
+		// both producing cdx.json) on the same layer would silently overwrite
+		// one another on disk.
+		modulesLayer, err := context.Layers.Get("modules")
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+		modulesLayer.Launch = true
+
+		goVersion, err := goVersionResolver.Resolve()
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
+		configDigest, err := writeBuildConfigurationDigest(config, goVersion)
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+		defer os.Remove(configDigest)
+
+		sum, err := checksumCalculator.Sum(context.WorkingDir, configDigest)
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
+		builtAt := clock.Now().Format(time.RFC3339Nano)
+
+		var binaries []string
+		var sbomFormats, moduleSBOMFormats []packit.SBOMFormat
+		cacheHit := false
+
+		if cachedSHA, ok := targetsLayer.Metadata["cache_sha"].(string); ok && cachedSHA == sum {
+			if cachedBuiltAt, ok := targetsLayer.Metadata["built_at"].(string); ok {
+				builtAt = cachedBuiltAt
+			}
+
+			if restored, ok := restoredBinaries(targetsLayer.Path, targetsLayer.Metadata); ok {
+				if formats, ok := restoreSBOMFormats(targetsLayer.Path, "sbom_formats", targetsLayer.Metadata); ok {
+					if moduleFormats, ok := restoreSBOMFormats(targetsLayer.Path, "module_sbom_formats", targetsLayer.Metadata); ok {
+						binaries = restored
+						sbomFormats = formats
+						moduleSBOMFormats = moduleFormats
+						cacheHit = true
+					}
+				}
+			}
+		}
+
+		targetsLayer.Launch = true
+		gocacheLayer.Cache = true
+
+		var binaryNames, sbomFormatExtensions, moduleSBOMFormatExtensions interface{}
+
+		goBuildConfig := GoBuildConfiguration{
+			Output:  filepath.Join(targetsLayer.Path, "bin"),
+			GoCache: gocacheLayer.Path,
+			Targets: config.Targets,
+		}
+
+		var predicate []byte
+		generateProvenance := func() error {
+			if !provenanceEnabled {
+				return nil
+			}
+
+			var err error
+			predicate, err = provenanceGenerator.Generate(ProvenanceSpec{
+				BuilderID:  fmt.Sprintf("%s@%s", context.BuildpackInfo.Name, context.BuildpackInfo.Version),
+				WorkingDir: context.WorkingDir,
+				Config:     goBuildConfig,
+				Binaries:   binaries,
+			})
+			return err
+		}
+
+		if cacheHit {
+			logger.Process("Reusing previously built binaries")
+
+			// Provenance must be generated before the source is cleared: it
+			// resolves the module graph and hashes go.mod/go.sum out of
+			// WorkingDir, which sourceRemover.Clear deletes.
+			if err := generateProvenance(); err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			err = sourceRemover.Clear(context.WorkingDir)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			binaryNames = targetsLayer.Metadata["binaries"]
+			sbomFormatExtensions = targetsLayer.Metadata["sbom_formats"]
+			moduleSBOMFormatExtensions = targetsLayer.Metadata["module_sbom_formats"]
+		} else {
+			path, goPath, err := pathManager.Setup(context.WorkingDir, config.ImportPath)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			goBuildConfig.Workspace = path
+			goBuildConfig.GoPath = goPath
+
+			binaries, err = buildProcess.Execute(goBuildConfig)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			err = pathManager.Teardown(goPath)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			moduleArtifact, err := moduleSBOMGenerator.Generate(context.WorkingDir)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			// Provenance must be generated before the source is cleared: it
+			// resolves the module graph and hashes go.mod/go.sum out of
+			// WorkingDir, which sourceRemover.Clear deletes.
+			if err := generateProvenance(); err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			err = sourceRemover.Clear(context.WorkingDir)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			artifact, err := sbomGenerator.Generate(filepath.Join(targetsLayer.Path, "bin"))
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			formatter, err := artifact.InFormats(context.BuildpackInfo.SBOMFormats...)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			moduleFormatter, err := moduleArtifact.InFormats(context.BuildpackInfo.SBOMFormats...)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			// persistSBOMFormats reads and writes its own copy of the generated
+			// SBOM content to the cache directory; the formatters are called again
+			// below so that the result served for this build reads the original,
+			// unread formatter output rather than the bytes re-read off disk.
+			_, extensions, err := persistSBOMFormats(targetsLayer.Path, "sbom_formats", formatter.Formats())
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			_, moduleExtensions, err := persistSBOMFormats(targetsLayer.Path, "module_sbom_formats", moduleFormatter.Formats())
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
+			sbomFormats = formatter.Formats()
+			moduleSBOMFormats = moduleFormatter.Formats()
+
+			names := make([]string, len(binaries))
+			for i, binary := range binaries {
+				names[i] = filepath.Base(binary)
+			}
+			binaryNames = names
+			sbomFormatExtensions = extensions
+			moduleSBOMFormatExtensions = moduleExtensions
+		}
+
+		targetsLayer.SBOM = packit.SBOMFormats(sbomFormats)
+		modulesLayer.SBOM = packit.SBOMFormats(moduleSBOMFormats)
+		targetsLayer.Metadata = map[string]interface{}{
+			"cache_sha":           sum,
+			"built_at":            builtAt,
+			"binaries":            binaryNames,
+			"sbom_formats":        sbomFormatExtensions,
+			"module_sbom_formats": moduleSBOMFormatExtensions,
+		}
+
+		layers := []packit.Layer{targetsLayer, gocacheLayer, modulesLayer}
+
+		if provenanceEnabled {
+			logger.Process("Generating build provenance")
+
+			provenanceLayer, err := context.Layers.Get("provenance")
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+			provenanceLayer.Launch = true
+
+			err = os.MkdirAll(provenanceLayer.Path, os.ModePerm)
+			if err != nil {
+				return packit.BuildResult{}, fmt.Errorf("failed to create provenance layer: %w", err)
+			}
+
+			err = os.WriteFile(filepath.Join(provenanceLayer.Path, "provenance.json"), predicate, 0600)
+			if err != nil {
+				return packit.BuildResult{}, fmt.Errorf("failed to write build provenance: %w", err)
+			}
+
+			targetsLayer.Metadata["provenance_sha256"] = provenanceDigest(predicate)
+			layers = append(layers, provenanceLayer)
+		}
+
+		logger.Process("Assigning launch processes")
+
+		labels := make([]string, len(binaries))
+		maxLen := 0
+		for i, binary := range binaries {
+			label := filepath.Base(binary)
+			if config.Targets[i].Default {
+				label = fmt.Sprintf("%s (default)", label)
+			}
+			if len(label) > maxLen {
+				maxLen = len(label)
+			}
+			labels[i] = label
+		}
+
+		var processes []packit.Process
+		for i, binary := range binaries {
+			logger.Subprocess("%s:%s %s", labels[i], strings.Repeat(" ", maxLen-len(labels[i])), binary)
+
+			process := packit.Process{
+				Type:    filepath.Base(binary),
+				Command: binary,
+				Direct:  true,
+			}
+			if !liveReloadEnabled {
+				process.Default = config.Targets[i].Default
+			}
+			processes = append(processes, process)
+
+			if liveReloadEnabled {
+				command, args, err := liveReloadConfigurer.Configure(context.Stack, context.WorkingDir, binary)
+				if err != nil {
+					return packit.BuildResult{}, err
+				}
+
+				processes = append(processes, packit.Process{
+					Type:    fmt.Sprintf("reload-%s", filepath.Base(binary)),
+					Command: command,
+					Args:    args,
+					Direct:  true,
+					Default: config.Targets[i].Default,
+				})
+			}
+		}
+
+		return packit.BuildResult{
+			Layers: layers,
+			Launch: packit.LaunchMetadata{
+				Processes: processes,
+			},
+		}, nil
+	}
+}
+
+func checkProvenanceEnabled() (bool, error) {
+	value, ok := os.LookupEnv("BP_GO_BUILD_PROVENANCE")
+	if !ok {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse BP_GO_BUILD_PROVENANCE value %s: %w", value, err)
+	}
+
+	return enabled, nil
+}
+
+func checkLiveReloadEnabled() (bool, error) {
+	value, ok := os.LookupEnv("BP_LIVE_RELOAD_ENABLED")
+	if !ok {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse BP_LIVE_RELOAD_ENABLED value %s: %w", value, err)
+	}
+
+	return enabled, nil
+}
@@ -0,0 +1,140 @@
+package gobuild
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/paketo-buildpacks/packit/v2"
+)
+
+// writeBuildConfigurationDigest renders the portions of a build that are not
+// already covered by ChecksumCalculator's file-content hashing of the
+// application source -- the resolved per-target build configuration, the Go
+// toolchain version, and CGO_ENABLED -- into a temporary file, so that it can
+// be included alongside the source when computing the targets layer's cache
+// checksum. goVersion identifies the external Go toolchain that will perform
+// the compile (see GoVersionResolver), not the version the buildpack binary
+// itself happens to be compiled with, so that upgrading that toolchain
+// invalidates the cache. The caller is responsible for removing the returned
+// file.
+func writeBuildConfigurationDigest(config BuildConfiguration, goVersion string) (string, error) {
+	payload, err := json.Marshal(struct {
+		Targets    []TargetSpec
+		GoVersion  string
+		CGOEnabled string
+	}{
+		Targets:    config.Targets,
+		GoVersion:  goVersion,
+		CGOEnabled: os.Getenv("CGO_ENABLED"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize build configuration: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "go-build-configuration-digest")
+	if err != nil {
+		return "", fmt.Errorf("failed to create build configuration digest: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(payload); err != nil {
+		return "", fmt.Errorf("failed to write build configuration digest: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
+// restoredBinaries reports whether every binary named in a previously
+// persisted "binaries" metadata entry is still present on disk in the
+// targets layer, returning their full paths when so. It reports ok=false if
+// the metadata entry is missing, malformed, or any binary has been evicted
+// from the layer, so that the caller can fall back to a real build.
+func restoredBinaries(layerPath string, metadata map[string]interface{}) (binaries []string, ok bool) {
+	raw, found := metadata["binaries"].([]interface{})
+	if !found || len(raw) == 0 {
+		return nil, false
+	}
+
+	for _, entry := range raw {
+		name, isString := entry.(string)
+		if !isString {
+			return nil, false
+		}
+
+		binary := filepath.Join(layerPath, "bin", name)
+		if _, err := os.Stat(binary); err != nil {
+			return nil, false
+		}
+
+		binaries = append(binaries, binary)
+	}
+
+	return binaries, true
+}
+
+// persistSBOMFormats writes each formatted SBOM to disk under layerPath, in
+// a subdirectory named for key, so that a future build with a matching cache
+// checksum can reuse it without re-invoking the SBOM generators. key also
+// identifies the metadata entry the caller stores the returned extensions
+// under, so that a layer carrying more than one independently-generated set
+// of SBOM formats (e.g. one for the compiled binaries, one for the resolved
+// module graph) can cache and restore each set separately. It returns the
+// list of extensions that were persisted, in the same order as formats, for
+// storage in layer metadata, along with equivalent SBOMFormats whose Content
+// readers have not yet been consumed by the write.
+func persistSBOMFormats(layerPath, key string, formats []packit.SBOMFormat) ([]packit.SBOMFormat, []string, error) {
+	dir := filepath.Join(layerPath, key)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, nil, fmt.Errorf("failed to create sbom cache directory: %w", err)
+	}
+
+	extensions := make([]string, len(formats))
+	persisted := make([]packit.SBOMFormat, len(formats))
+	for i, format := range formats {
+		content, err := io.ReadAll(format.Content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read sbom content: %w", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%d.%s", i, format.Extension)), content, 0600); err != nil {
+			return nil, nil, fmt.Errorf("failed to cache sbom content: %w", err)
+		}
+
+		extensions[i] = format.Extension
+		persisted[i] = packit.SBOMFormat{Extension: format.Extension, Content: bytes.NewReader(content)}
+	}
+
+	return persisted, extensions, nil
+}
+
+// restoreSBOMFormats reconstructs the SBOM formats persisted by a previous
+// call to persistSBOMFormats under the same key, reporting ok=false if the
+// metadata entry is missing, malformed, or any of the cached files has been
+// evicted.
+func restoreSBOMFormats(layerPath, key string, metadata map[string]interface{}) ([]packit.SBOMFormat, bool) {
+	raw, found := metadata[key].([]interface{})
+	if !found || len(raw) == 0 {
+		return nil, false
+	}
+
+	formats := make([]packit.SBOMFormat, len(raw))
+	for i, entry := range raw {
+		extension, isString := entry.(string)
+		if !isString {
+			return nil, false
+		}
+
+		content, err := os.ReadFile(filepath.Join(layerPath, key, fmt.Sprintf("%d.%s", i, extension)))
+		if err != nil {
+			return nil, false
+		}
+
+		formats[i] = packit.SBOMFormat{Extension: extension, Content: bytes.NewReader(content)}
+	}
+
+	return formats, true
+}
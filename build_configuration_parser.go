@@ -0,0 +1,150 @@
+package gobuild
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// BuildConfigurationParser parses BuildConfiguration from buildpack.yml and
+// the build environment variables that this buildpack supports:
+//
+//	BP_GO_TARGETS       a colon-separated list of import paths to build
+//	BP_GO_BUILD_FLAGS   a space-separated list of flags passed to `go build`
+//	BP_GO_BUILD_LDFLAGS a string passed through as the `-ldflags` value
+//
+// A [[go.targets]] table in buildpack.yml, when present, takes precedence
+// over the flat environment variables above and allows each target to carry
+// its own flags, ldflags, tags, env, cgo_enabled, and output_name.
+type BuildConfigurationParser struct{}
+
+// NewBuildConfigurationParser creates an instance of a BuildConfigurationParser.
+func NewBuildConfigurationParser() BuildConfigurationParser {
+	return BuildConfigurationParser{}
+}
+
+type buildpackYML struct {
+	Go struct {
+		Targets []TargetSpec `toml:"targets"`
+	} `toml:"go"`
+}
+
+func (p BuildConfigurationParser) Parse(workingDir, buildpackVersion string) (BuildConfiguration, error) {
+	config := BuildConfiguration{ImportPath: "."}
+
+	targets, err := parseTargetsFromBuildpackYML(workingDir)
+	if err != nil {
+		return BuildConfiguration{}, err
+	}
+
+	if targets == nil {
+		targets, err = parseTargetsFromEnv()
+		if err != nil {
+			return BuildConfiguration{}, err
+		}
+	}
+
+	config.Targets = withDefaultTarget(targets)
+
+	if importPath, ok := os.LookupEnv("BP_GO_BUILD_IMPORT_PATH"); ok {
+		config.ImportPath = importPath
+	}
+
+	return config, nil
+}
+
+// parseTargetsFromBuildpackYML reads the structured [[go.targets]] table
+// from buildpack.yml, if one is present. It returns a nil slice (not an
+// error) when buildpack.yml does not exist or declares no targets, so that
+// Parse can fall back to the flat environment variables.
+func parseTargetsFromBuildpackYML(workingDir string) ([]TargetSpec, error) {
+	path := filepath.Join(workingDir, "buildpack.yml")
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to parse buildpack.yml: %w", err)
+	}
+
+	var parsed buildpackYML
+	if _, err := toml.DecodeFile(path, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse buildpack.yml: %w", err)
+	}
+
+	if len(parsed.Go.Targets) == 0 {
+		return nil, nil
+	}
+
+	for i, target := range parsed.Go.Targets {
+		if target.Path == "" {
+			return nil, fmt.Errorf("failed to parse buildpack.yml: go.targets[%d] is missing a path", i)
+		}
+	}
+
+	return parsed.Go.Targets, nil
+}
+
+// parseTargetsFromEnv expands the legacy flat BP_GO_TARGETS,
+// BP_GO_BUILD_FLAGS, and BP_GO_BUILD_LDFLAGS environment variables into the
+// structured TargetSpec form, applying the same flags and ldflags to every
+// target.
+func parseTargetsFromEnv() ([]TargetSpec, error) {
+	paths := []string{"."}
+
+	if value, ok := os.LookupEnv("BP_GO_TARGETS"); ok {
+		var parsed []string
+		for _, target := range strings.Split(value, ":") {
+			target = strings.TrimSpace(target)
+			if target == "" {
+				continue
+			}
+			parsed = append(parsed, target)
+		}
+
+		if len(parsed) == 0 {
+			return nil, fmt.Errorf("failed to parse BP_GO_TARGETS: no targets found in %q", value)
+		}
+
+		paths = parsed
+	}
+
+	var flags []string
+	if value, ok := os.LookupEnv("BP_GO_BUILD_FLAGS"); ok {
+		flags = strings.Fields(value)
+	}
+
+	var ldflags string
+	if value, ok := os.LookupEnv("BP_GO_BUILD_LDFLAGS"); ok {
+		ldflags = value
+	}
+
+	targets := make([]TargetSpec, len(paths))
+	for i, path := range paths {
+		targets[i] = TargetSpec{
+			Path:    path,
+			Flags:   flags,
+			LDFlags: ldflags,
+		}
+	}
+
+	return targets, nil
+}
+
+// withDefaultTarget marks the first target as the default when none of the
+// given targets already declares itself as the default.
+func withDefaultTarget(targets []TargetSpec) []TargetSpec {
+	for _, target := range targets {
+		if target.Default {
+			return targets
+		}
+	}
+
+	if len(targets) > 0 {
+		targets[0].Default = true
+	}
+
+	return targets
+}
@@ -0,0 +1,104 @@
+package gobuild
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+)
+
+// GoBuildConfiguration is the full set of inputs required to invoke `go
+// build` for a given workspace.
+type GoBuildConfiguration struct {
+	// Workspace is the location of the Go module to build.
+	Workspace string
+
+	// Output is the directory that compiled binaries are written into.
+	Output string
+
+	// GoPath is the GOPATH that the build is executed with.
+	GoPath string
+
+	// GoCache is the GOCACHE that the build is executed with.
+	GoCache string
+
+	// Targets is the list of per-target build configurations to compile, each
+	// producing its own binary.
+	Targets []TargetSpec
+}
+
+//go:generate faux --interface BuildProcess --output fakes/build_process.go
+
+// BuildProcess executes a Go build for a resolved GoBuildConfiguration and
+// reports the paths of the binaries that were produced.
+type BuildProcess interface {
+	Execute(config GoBuildConfiguration) ([]string, error)
+}
+
+// GoBuildProcess invokes the `go build` command line tool.
+type GoBuildProcess struct {
+	executable pexec.Executable
+	logger     scribe.Emitter
+}
+
+// NewGoBuildProcess creates an instance of a GoBuildProcess.
+func NewGoBuildProcess(executable pexec.Executable, logger scribe.Emitter) GoBuildProcess {
+	return GoBuildProcess{
+		executable: executable,
+		logger:     logger,
+	}
+}
+
+func (p GoBuildProcess) Execute(config GoBuildConfiguration) ([]string, error) {
+	var binaries []string
+
+	for _, target := range config.Targets {
+		name := target.OutputName
+		if name == "" {
+			name = filepath.Base(target.Path)
+		}
+		output := filepath.Join(config.Output, name)
+
+		args := append([]string{"build"}, target.Flags...)
+		if target.LDFlags != "" {
+			args = append(args, "-ldflags", target.LDFlags)
+		}
+		if len(target.Tags) > 0 {
+			args = append(args, "-tags", strings.Join(target.Tags, ","))
+		}
+		args = append(args, "-o", output, target.Path)
+
+		env := append(os.Environ(), fmt.Sprintf("GOPATH=%s", config.GoPath), fmt.Sprintf("GOCACHE=%s", config.GoCache))
+		if target.CGOEnabled != nil {
+			cgoEnabled := "0"
+			if *target.CGOEnabled {
+				cgoEnabled = "1"
+			}
+			env = append(env, fmt.Sprintf("CGO_ENABLED=%s", cgoEnabled))
+		}
+		for key, value := range target.Env {
+			env = append(env, fmt.Sprintf("%s=%s", key, value))
+		}
+
+		buffer := bytes.NewBuffer(nil)
+		err := p.executable.Execute(pexec.Execution{
+			Args:   args,
+			Dir:    config.Workspace,
+			Env:    env,
+			Stdout: buffer,
+			Stderr: buffer,
+		})
+		if err != nil {
+			p.logger.Detail(buffer.String())
+			return nil, fmt.Errorf("failed to execute build process for target %q: %w", target.Path, err)
+		}
+
+		binaries = append(binaries, output)
+	}
+
+	return binaries, nil
+}
@@ -2,8 +2,10 @@ package gobuild_test
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -31,12 +33,16 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		timestamp  time.Time
 		logs       *bytes.Buffer
 
-		buildProcess       *fakes.BuildProcess
-		pathManager        *fakes.PathManager
-		sourceRemover      *fakes.SourceRemover
-		parser             *fakes.ConfigurationParser
-		checksumCalculator *fakes.ChecksumCalculator
-		sbomGenerator      *fakes.SBOMGenerator
+		buildProcess         *fakes.BuildProcess
+		pathManager          *fakes.PathManager
+		sourceRemover        *fakes.SourceRemover
+		parser               *fakes.ConfigurationParser
+		checksumCalculator   *fakes.ChecksumCalculator
+		sbomGenerator        *fakes.SBOMGenerator
+		moduleSBOMGenerator  *fakes.ModuleSBOMGenerator
+		liveReloadConfigurer *fakes.LiveReloadConfigurer
+		provenanceGenerator  *fakes.ProvenanceGenerator
+		goVersionResolver    *fakes.GoVersionResolver
 
 		build packit.BuildFunc
 	)
@@ -73,14 +79,27 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 
 		parser = &fakes.ConfigurationParser{}
 		parser.ParseCall.Returns.BuildConfiguration = gobuild.BuildConfiguration{
-			Targets:    []string{"some-target", "other-target"},
-			Flags:      []string{"some-flag", "other-flag"},
+			Targets: []gobuild.TargetSpec{
+				{Path: "some-target", Flags: []string{"some-flag", "other-flag"}, Default: true},
+				{Path: "other-target", Flags: []string{"some-flag", "other-flag"}},
+			},
 			ImportPath: "some-import-path",
 		}
 
 		sbomGenerator = &fakes.SBOMGenerator{}
 		sbomGenerator.GenerateCall.Returns.SBOM = sbom.SBOM{}
 
+		moduleSBOMGenerator = &fakes.ModuleSBOMGenerator{}
+		moduleSBOMGenerator.GenerateCall.Returns.SBOM = sbom.SBOM{}
+
+		liveReloadConfigurer = &fakes.LiveReloadConfigurer{}
+
+		provenanceGenerator = &fakes.ProvenanceGenerator{}
+		provenanceGenerator.GenerateCall.Returns.Bytes = []byte(`{"some":"provenance"}`)
+
+		goVersionResolver = &fakes.GoVersionResolver{}
+		goVersionResolver.ResolveCall.Returns.String = "go1.20"
+
 		build = gobuild.Build(
 			parser,
 			buildProcess,
@@ -90,6 +109,10 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			scribe.NewEmitter(logs),
 			sourceRemover,
 			sbomGenerator,
+			moduleSBOMGenerator,
+			liveReloadConfigurer,
+			provenanceGenerator,
+			goVersionResolver,
 		)
 	})
 
@@ -113,29 +136,42 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		})
 		Expect(err).NotTo(HaveOccurred())
 
-		Expect(result.Layers).To(HaveLen(2))
+		Expect(result.Layers).To(HaveLen(3))
 
 		targets := result.Layers[0]
 		Expect(targets.Name).To(Equal("targets"))
 		Expect(targets.Path).To(Equal(filepath.Join(layersDir, "targets")))
 		Expect(targets.Metadata).To(Equal(map[string]interface{}{
-			"cache_sha": "some-checksum",
-			"built_at":  timestamp.Format(time.RFC3339Nano),
+			"cache_sha":           "some-checksum",
+			"built_at":            timestamp.Format(time.RFC3339Nano),
+			"binaries":            []string{"some-start-command", "another-start-command"},
+			"sbom_formats":        []string{"cdx.json", "spdx.json"},
+			"module_sbom_formats": []string{"cdx.json", "spdx.json"},
 		}))
 		Expect(targets.Build).To(BeFalse())
 		Expect(targets.Cache).To(BeFalse())
 		Expect(targets.Launch).To(BeTrue())
 
-		Expect(targets.SBOM.Formats()).To(Equal([]packit.SBOMFormat{
-			{
-				Extension: sbom.Format(sbom.CycloneDXFormat).Extension(),
-				Content:   sbom.NewFormattedReader(sbom.SBOM{}, sbom.CycloneDXFormat),
-			},
-			{
-				Extension: sbom.Format(sbom.SPDXFormat).Extension(),
-				Content:   sbom.NewFormattedReader(sbom.SBOM{}, sbom.SPDXFormat),
-			},
-		}))
+		// FormattedReader carries unexported encoder/decoder/validator funcs,
+		// which reflect.DeepEqual never considers equal across two separately
+		// constructed instances, so the generated formats are compared by their
+		// rendered content rather than by the Content reader's struct identity.
+		assertFormats := func(formats []packit.SBOMFormat, expectedFormats []sbom.Format) {
+			Expect(formats).To(HaveLen(len(expectedFormats)))
+
+			for i, expectedFormat := range expectedFormats {
+				Expect(formats[i].Extension).To(Equal(sbom.Format(expectedFormat).Extension()))
+
+				actualContent, err := io.ReadAll(formats[i].Content)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedContent, err := io.ReadAll(sbom.NewFormattedReader(sbom.SBOM{}, expectedFormat))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(actualContent).To(Equal(expectedContent))
+			}
+		}
+		assertFormats(targets.SBOM.Formats(), []sbom.Format{sbom.CycloneDXFormat, sbom.SPDXFormat})
 
 		gocache := result.Layers[1]
 		Expect(gocache.Name).To(Equal("gocache"))
@@ -144,6 +180,14 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect(gocache.Cache).To(BeTrue())
 		Expect(gocache.Launch).To(BeFalse())
 
+		modules := result.Layers[2]
+		Expect(modules.Name).To(Equal("modules"))
+		Expect(modules.Path).To(Equal(filepath.Join(layersDir, "modules")))
+		Expect(modules.Build).To(BeFalse())
+		Expect(modules.Cache).To(BeFalse())
+		Expect(modules.Launch).To(BeTrue())
+		assertFormats(modules.SBOM.Formats(), []sbom.Format{sbom.CycloneDXFormat, sbom.SPDXFormat})
+
 		Expect(result.Launch.Processes).To(Equal([]packit.Process{
 			{
 				Type:    "some-start-command",
@@ -169,14 +213,17 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			Output:    filepath.Join(layersDir, "targets", "bin"),
 			GoPath:    "some-go-path",
 			GoCache:   filepath.Join(layersDir, "gocache"),
-			Flags:     []string{"some-flag", "other-flag"},
-			Targets:   []string{"some-target", "other-target"},
+			Targets: []gobuild.TargetSpec{
+				{Path: "some-target", Flags: []string{"some-flag", "other-flag"}, Default: true},
+				{Path: "other-target", Flags: []string{"some-flag", "other-flag"}},
+			},
 		}))
 
 		Expect(pathManager.TeardownCall.Receives.GoPath).To(Equal("some-go-path"))
 
 		Expect(sourceRemover.ClearCall.Receives.Path).To(Equal(workingDir))
 		Expect(sbomGenerator.GenerateCall.Receives.Dir).To(Equal(filepath.Join(targets.Path, "bin")))
+		Expect(moduleSBOMGenerator.GenerateCall.Receives.WorkingDir).To(Equal(workingDir))
 
 		Expect(logs.String()).To(ContainSubstring("Some Buildpack some-version"))
 		Expect(logs.String()).To(ContainSubstring("Assigning launch processes"))
@@ -184,9 +231,102 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect(logs.String()).To(ContainSubstring("another-start-command:        path/another-start-command"))
 	})
 
+	context("when targets declare their own per-target build configuration", func() {
+		it.Before(func() {
+			cgoEnabled := false
+			parser.ParseCall.Returns.BuildConfiguration = gobuild.BuildConfiguration{
+				Targets: []gobuild.TargetSpec{
+					{
+						Path:       "some-target",
+						Flags:      []string{"-trimpath"},
+						LDFlags:    "-s -w",
+						Tags:       []string{"prod"},
+						Env:        map[string]string{"SOME_VAR": "some-value"},
+						CGOEnabled: &cgoEnabled,
+						OutputName: "server",
+						Default:    true,
+					},
+					{
+						Path: "other-target",
+						Env:  map[string]string{"OTHER_VAR": "other-value"},
+					},
+				},
+				ImportPath: "some-import-path",
+			}
+		})
+
+		it("passes each target's configuration through to the build process unchanged", func() {
+			_, err := build(packit.BuildContext{
+				WorkingDir: workingDir,
+				CNBPath:    cnbDir,
+				Stack:      "some-stack",
+				BuildpackInfo: packit.BuildpackInfo{
+					Name:    "Some Buildpack",
+					Version: "some-version",
+				},
+				Layers: packit.Layers{Path: layersDir},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buildProcess.ExecuteCall.Receives.Config.Targets).To(Equal(parser.ParseCall.Returns.BuildConfiguration.Targets))
+		})
+	})
+
+	context("when a non-first target is marked as the default", func() {
+		it.Before(func() {
+			parser.ParseCall.Returns.BuildConfiguration = gobuild.BuildConfiguration{
+				Targets: []gobuild.TargetSpec{
+					{Path: "some-target"},
+					{Path: "other-target", Default: true},
+				},
+				ImportPath: "some-import-path",
+			}
+		})
+
+		it("honors the overridden default", func() {
+			result, err := build(packit.BuildContext{
+				WorkingDir: workingDir,
+				CNBPath:    cnbDir,
+				Stack:      "some-stack",
+				BuildpackInfo: packit.BuildpackInfo{
+					Name:    "Some Buildpack",
+					Version: "some-version",
+				},
+				Layers: packit.Layers{Path: layersDir},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Launch.Processes).To(Equal([]packit.Process{
+				{
+					Type:    "some-start-command",
+					Command: "path/some-start-command",
+					Direct:  true,
+				},
+				{
+					Type:    "another-start-command",
+					Command: "path/another-start-command",
+					Direct:  true,
+					Default: true,
+				},
+			}))
+		})
+	})
+
 	context("BP_LIVE_RELOAD_ENABLED=true in the build environment", func() {
 		it.Before(func() {
 			os.Setenv("BP_LIVE_RELOAD_ENABLED", "true")
+			liveReloadConfigurer.ConfigureCall.Stub = func(stack, workingDir, binary string) (string, []string, error) {
+				if binary == "" {
+					return "", nil, nil
+				}
+				return "watchexec", []string{
+					"--restart",
+					"--watch", workingDir,
+					"--watch", filepath.Dir(binary),
+					"--",
+					binary,
+				}, nil
+			}
 		})
 
 		it.After(func() {
@@ -245,6 +385,128 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("when BP_GO_BUILD_PROVENANCE is not set", func() {
+		it("does not generate a build provenance attestation", func() {
+			result, err := build(packit.BuildContext{
+				WorkingDir: workingDir,
+				CNBPath:    cnbDir,
+				Stack:      "some-stack",
+				BuildpackInfo: packit.BuildpackInfo{
+					Name:    "Some Buildpack",
+					Version: "some-version",
+				},
+				Layers: packit.Layers{Path: layersDir},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(provenanceGenerator.GenerateCall.CallCount).To(Equal(0))
+			Expect(result.Layers).To(HaveLen(3))
+		})
+	})
+
+	context("BP_GO_BUILD_PROVENANCE=true in the build environment", func() {
+		var binaryPath string
+
+		it.Before(func() {
+			os.Setenv("BP_GO_BUILD_PROVENANCE", "true")
+
+			Expect(os.MkdirAll(filepath.Join(layersDir, "targets", "bin"), os.ModePerm)).To(Succeed())
+			binaryPath = filepath.Join(layersDir, "targets", "bin", "some-start-command")
+			Expect(os.WriteFile(binaryPath, []byte("some-binary-content"), 0600)).To(Succeed())
+
+			buildProcess.ExecuteCall.Returns.Binaries = []string{binaryPath}
+		})
+
+		it.After(func() {
+			os.Unsetenv("BP_GO_BUILD_PROVENANCE")
+		})
+
+		it("writes a build provenance attestation into a dedicated launch layer", func() {
+			result, err := build(packit.BuildContext{
+				WorkingDir: workingDir,
+				CNBPath:    cnbDir,
+				Stack:      "some-stack",
+				BuildpackInfo: packit.BuildpackInfo{
+					Name:    "Some Buildpack",
+					Version: "some-version",
+				},
+				Layers: packit.Layers{Path: layersDir},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(provenanceGenerator.GenerateCall.CallCount).To(Equal(1))
+			Expect(provenanceGenerator.GenerateCall.Receives.Spec.BuilderID).To(Equal("Some Buildpack@some-version"))
+			Expect(provenanceGenerator.GenerateCall.Receives.Spec.WorkingDir).To(Equal(workingDir))
+			Expect(provenanceGenerator.GenerateCall.Receives.Spec.Binaries).To(Equal([]string{binaryPath}))
+
+			Expect(result.Layers).To(HaveLen(4))
+			provenance := result.Layers[3]
+			Expect(provenance.Name).To(Equal("provenance"))
+			Expect(provenance.Path).To(Equal(filepath.Join(layersDir, "provenance")))
+			Expect(provenance.Launch).To(BeTrue())
+
+			content, err := os.ReadFile(filepath.Join(provenance.Path, "provenance.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal([]byte(`{"some":"provenance"}`)))
+
+			targets := result.Layers[0]
+			Expect(targets.Metadata["provenance_sha256"]).To(Equal(fmt.Sprintf("%x", sha256.Sum256([]byte(`{"some":"provenance"}`)))))
+		})
+
+		it("generates the provenance attestation before the application source is cleared", func() {
+			marker := filepath.Join(workingDir, "go.mod")
+			Expect(os.WriteFile(marker, []byte("module some-module\n"), 0600)).To(Succeed())
+
+			provenanceGenerator.GenerateCall.Stub = func(spec gobuild.ProvenanceSpec) ([]byte, error) {
+				if _, err := os.Stat(marker); err != nil {
+					return nil, fmt.Errorf("expected %s to still exist when provenance is generated: %w", marker, err)
+				}
+				return []byte(`{"some":"provenance"}`), nil
+			}
+
+			sourceRemover.ClearCall.Stub = func(path string) error {
+				return os.RemoveAll(marker)
+			}
+
+			_, err := build(packit.BuildContext{
+				WorkingDir: workingDir,
+				CNBPath:    cnbDir,
+				Stack:      "some-stack",
+				BuildpackInfo: packit.BuildpackInfo{
+					Name:    "Some Buildpack",
+					Version: "some-version",
+				},
+				Layers: packit.Layers{Path: layersDir},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(provenanceGenerator.GenerateCall.CallCount).To(Equal(1))
+
+			_, statErr := os.Stat(marker)
+			Expect(os.IsNotExist(statErr)).To(BeTrue())
+		})
+
+		context("when the provenance generator fails", func() {
+			it.Before(func() {
+				provenanceGenerator.GenerateCall.Returns.Error = errors.New("failed to generate provenance")
+			})
+
+			it("fails the build", func() {
+				_, err := build(packit.BuildContext{
+					WorkingDir: workingDir,
+					CNBPath:    cnbDir,
+					Stack:      "some-stack",
+					BuildpackInfo: packit.BuildpackInfo{
+						Name:    "Some Buildpack",
+						Version: "some-version",
+					},
+					Layers: packit.Layers{Path: layersDir},
+				})
+				Expect(err).To(MatchError("failed to generate provenance"))
+			})
+		})
+	})
+
 	context("when the stack is tiny", func() {
 		it("marks the launch process as direct", func() {
 			result, err := build(packit.BuildContext{
@@ -299,13 +561,16 @@ launch = true
 			})
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(result.Layers).To(HaveLen(2))
+			Expect(result.Layers).To(HaveLen(3))
 			targets := result.Layers[0]
 			Expect(targets.Name).To(Equal("targets"))
 			Expect(targets.Path).To(Equal(filepath.Join(layersDir, "targets")))
 			Expect(targets.Metadata).To(Equal(map[string]interface{}{
-				"cache_sha": "some-checksum",
-				"built_at":  timestamp.Add(-10 * time.Second).Format(time.RFC3339Nano),
+				"cache_sha":           "some-checksum",
+				"built_at":            timestamp.Add(-10 * time.Second).Format(time.RFC3339Nano),
+				"binaries":            []string{"some-start-command", "another-start-command"},
+				"sbom_formats":        []string{},
+				"module_sbom_formats": []string{},
 			}))
 			Expect(targets.Build).To(BeFalse())
 			Expect(targets.Cache).To(BeFalse())
@@ -313,6 +578,181 @@ launch = true
 		})
 	})
 
+	context("when the targets layer has intact cached binaries and SBOMs matching the checksum", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(layersDir, "targets", "bin"), os.ModePerm)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(layersDir, "targets", "bin", "some-start-command"), []byte("some-binary"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(layersDir, "targets", "bin", "another-start-command"), []byte("another-binary"), 0600)).To(Succeed())
+
+			Expect(os.MkdirAll(filepath.Join(layersDir, "targets", "sbom_formats"), os.ModePerm)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(layersDir, "targets", "sbom_formats", "0.cdx.json"), []byte(`{"some":"sbom"}`), 0600)).To(Succeed())
+
+			Expect(os.MkdirAll(filepath.Join(layersDir, "targets", "module_sbom_formats"), os.ModePerm)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(layersDir, "targets", "module_sbom_formats", "0.cdx.json"), []byte(`{"some":"module-sbom"}`), 0600)).To(Succeed())
+
+			err := ioutil.WriteFile(filepath.Join(layersDir, "targets.toml"), []byte(fmt.Sprintf(`
+launch = true
+[metadata]
+	cache_sha = "some-checksum"
+	built_at = "%s"
+	binaries = ["some-start-command", "another-start-command"]
+	sbom_formats = ["cdx.json"]
+	module_sbom_formats = ["cdx.json"]
+`, timestamp.Add(-10*time.Second).Format(time.RFC3339Nano))), 0600)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it("reuses the previously built binaries without recompiling", func() {
+			result, err := build(packit.BuildContext{
+				WorkingDir: workingDir,
+				CNBPath:    cnbDir,
+				Stack:      "some-stack",
+				BuildpackInfo: packit.BuildpackInfo{
+					Name:    "Some Buildpack",
+					Version: "some-version",
+				},
+				Layers: packit.Layers{Path: layersDir},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buildProcess.ExecuteCall.CallCount).To(Equal(0))
+			Expect(sbomGenerator.GenerateCall.CallCount).To(Equal(0))
+			Expect(moduleSBOMGenerator.GenerateCall.CallCount).To(Equal(0))
+
+			Expect(sourceRemover.ClearCall.Receives.Path).To(Equal(workingDir))
+
+			targets := result.Layers[0]
+			Expect(targets.SBOM.Formats()).To(Equal([]packit.SBOMFormat{
+				{Extension: "cdx.json", Content: bytes.NewReader([]byte(`{"some":"sbom"}`))},
+			}))
+
+			modules := result.Layers[2]
+			Expect(modules.Name).To(Equal("modules"))
+			Expect(modules.SBOM.Formats()).To(Equal([]packit.SBOMFormat{
+				{Extension: "cdx.json", Content: bytes.NewReader([]byte(`{"some":"module-sbom"}`))},
+			}))
+
+			Expect(result.Launch.Processes).To(Equal([]packit.Process{
+				{
+					Type:    "some-start-command",
+					Command: filepath.Join(layersDir, "targets", "bin", "some-start-command"),
+					Direct:  true,
+					Default: true,
+				},
+				{
+					Type:    "another-start-command",
+					Command: filepath.Join(layersDir, "targets", "bin", "another-start-command"),
+					Direct:  true,
+				},
+			}))
+		})
+
+		context("when BP_GO_BUILD_PROVENANCE=true", func() {
+			it.Before(func() {
+				os.Setenv("BP_GO_BUILD_PROVENANCE", "true")
+			})
+
+			it.After(func() {
+				os.Unsetenv("BP_GO_BUILD_PROVENANCE")
+			})
+
+			it("generates the provenance attestation before the application source is cleared", func() {
+				marker := filepath.Join(workingDir, "go.mod")
+				Expect(os.WriteFile(marker, []byte("module some-module\n"), 0600)).To(Succeed())
+
+				provenanceGenerator.GenerateCall.Stub = func(spec gobuild.ProvenanceSpec) ([]byte, error) {
+					if _, err := os.Stat(marker); err != nil {
+						return nil, fmt.Errorf("expected %s to still exist when provenance is generated: %w", marker, err)
+					}
+					return []byte(`{"some":"provenance"}`), nil
+				}
+
+				sourceRemover.ClearCall.Stub = func(path string) error {
+					return os.RemoveAll(marker)
+				}
+
+				_, err := build(packit.BuildContext{
+					WorkingDir: workingDir,
+					CNBPath:    cnbDir,
+					Stack:      "some-stack",
+					BuildpackInfo: packit.BuildpackInfo{
+						Name:    "Some Buildpack",
+						Version: "some-version",
+					},
+					Layers: packit.Layers{Path: layersDir},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(provenanceGenerator.GenerateCall.CallCount).To(Equal(1))
+
+				_, statErr := os.Stat(marker)
+				Expect(os.IsNotExist(statErr)).To(BeTrue())
+			})
+		})
+	})
+
+	context("when the resolved build configuration no longer matches the cached checksum", func() {
+		it.Before(func() {
+			checksumCalculator.SumCall.Returns.String = "some-other-checksum"
+
+			err := ioutil.WriteFile(filepath.Join(layersDir, "targets.toml"), []byte(fmt.Sprintf(`
+launch = true
+[metadata]
+	cache_sha = "some-checksum"
+	built_at = "%s"
+	binaries = ["some-start-command", "another-start-command"]
+	sbom_formats = ["cdx.json"]
+`, timestamp.Add(-10*time.Second).Format(time.RFC3339Nano))), 0600)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it("recompiles the binaries", func() {
+			_, err := build(packit.BuildContext{
+				WorkingDir: workingDir,
+				CNBPath:    cnbDir,
+				Stack:      "some-stack",
+				BuildpackInfo: packit.BuildpackInfo{
+					Name:    "Some Buildpack",
+					Version: "some-version",
+				},
+				Layers: packit.Layers{Path: layersDir},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buildProcess.ExecuteCall.CallCount).To(Equal(1))
+		})
+	})
+
+	context("when the cached binaries have been evicted from the targets layer despite a matching checksum", func() {
+		it.Before(func() {
+			err := ioutil.WriteFile(filepath.Join(layersDir, "targets.toml"), []byte(fmt.Sprintf(`
+launch = true
+[metadata]
+	cache_sha = "some-checksum"
+	built_at = "%s"
+	binaries = ["some-start-command", "another-start-command"]
+	sbom_formats = ["cdx.json"]
+`, timestamp.Add(-10*time.Second).Format(time.RFC3339Nano))), 0600)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it("falls back to a real build", func() {
+			_, err := build(packit.BuildContext{
+				WorkingDir: workingDir,
+				CNBPath:    cnbDir,
+				Stack:      "some-stack",
+				BuildpackInfo: packit.BuildpackInfo{
+					Name:    "Some Buildpack",
+					Version: "some-version",
+				},
+				Layers: packit.Layers{Path: layersDir},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buildProcess.ExecuteCall.CallCount).To(Equal(1))
+		})
+	})
+
 	context("failure cases", func() {
 		context("when the targets layer cannot be retrieved", func() {
 			it.Before(func() {
@@ -356,6 +796,26 @@ launch = true
 			})
 		})
 
+		context("when the go toolchain version cannot be resolved", func() {
+			it.Before(func() {
+				goVersionResolver.ResolveCall.Returns.Error = errors.New("failed to determine go version")
+			})
+
+			it("returns an error", func() {
+				_, err := build(packit.BuildContext{
+					WorkingDir: workingDir,
+					CNBPath:    cnbDir,
+					Stack:      "some-stack",
+					BuildpackInfo: packit.BuildpackInfo{
+						Name:    "Some Buildpack",
+						Version: "some-version",
+					},
+					Layers: packit.Layers{Path: layersDir},
+				})
+				Expect(err).To(MatchError("failed to determine go version"))
+			})
+		})
+
 		context("when the go path cannot be setup", func() {
 			it.Before(func() {
 				pathManager.SetupCall.Returns.Err = errors.New("failed to setup go path")
@@ -378,10 +838,10 @@ launch = true
 
 		context("when the build process fails", func() {
 			it.Before(func() {
-				buildProcess.ExecuteCall.Returns.Err = errors.New("failed to execute build process")
+				buildProcess.ExecuteCall.Returns.Err = errors.New(`failed to execute build process for target "other-target": exit status 1`)
 			})
 
-			it("returns an error", func() {
+			it("returns an error naming the target that failed", func() {
 				_, err := build(packit.BuildContext{
 					WorkingDir: workingDir,
 					CNBPath:    cnbDir,
@@ -392,7 +852,7 @@ launch = true
 					},
 					Layers: packit.Layers{Path: layersDir},
 				})
-				Expect(err).To(MatchError("failed to execute build process"))
+				Expect(err).To(MatchError(`failed to execute build process for target "other-target": exit status 1`))
 			})
 		})
 
@@ -480,6 +940,7 @@ launch = true
 		context("when stack is tiny and BP_LIVE_RELOAD_ENABLED=true in the build environment", func() {
 			it.Before(func() {
 				os.Setenv("BP_LIVE_RELOAD_ENABLED", "true")
+				liveReloadConfigurer.ConfigureCall.Returns.Err = errors.New("cannot enable live reload on stack 'io.paketo.stacks.tiny': stack does not support watchexec")
 			})
 
 			it.After(func() {
@@ -499,6 +960,30 @@ launch = true
 				Expect(err).To(MatchError(ContainSubstring("cannot enable live reload on stack 'io.paketo.stacks.tiny': stack does not support watchexec")))
 			})
 		})
+		context("when BP_LIVE_RELOAD_ENABLED=true and the configured runner is unknown", func() {
+			it.Before(func() {
+				os.Setenv("BP_LIVE_RELOAD_ENABLED", "true")
+				liveReloadConfigurer.ConfigureCall.Returns.Err = errors.New(`unknown live reload runner "some-runner"`)
+			})
+
+			it.After(func() {
+				os.Unsetenv("BP_LIVE_RELOAD_ENABLED")
+			})
+
+			it("fails the build and returns the error", func() {
+				_, err := build(packit.BuildContext{
+					WorkingDir: workingDir,
+					CNBPath:    cnbDir,
+					Stack:      "some-stack",
+					BuildpackInfo: packit.BuildpackInfo{
+						Name:    "Some Buildpack",
+						Version: "some-version",
+					},
+					Layers: packit.Layers{Path: layersDir},
+				})
+				Expect(err).To(MatchError(`unknown live reload runner "some-runner"`))
+			})
+		})
 		context("when an SBOM cannot be generated", func() {
 			it.Before(func() {
 				sbomGenerator.GenerateCall.Returns.Error = errors.New("sbom generation error")
@@ -517,6 +1002,25 @@ launch = true
 				Expect(err).To(MatchError("sbom generation error"))
 			})
 		})
+		context("when a module SBOM cannot be generated", func() {
+			it.Before(func() {
+				moduleSBOMGenerator.GenerateCall.Returns.Error = errors.New("module sbom generation error")
+			})
+			it("fails the build and returns the error", func() {
+				_, err := build(packit.BuildContext{
+					WorkingDir: workingDir,
+					CNBPath:    cnbDir,
+					Stack:      "io.paketo.stacks.tiny",
+					BuildpackInfo: packit.BuildpackInfo{
+						Name:    "Some Buildpack",
+						Version: "some-version",
+					},
+					Layers: packit.Layers{Path: layersDir},
+				})
+				Expect(err).To(MatchError("module sbom generation error"))
+			})
+		})
+
 		context("when a requested SBOM format is invalid", func() {
 			it("fails the build and returns the error", func() {
 				_, err := build(packit.BuildContext{
@@ -530,7 +1034,7 @@ launch = true
 					},
 					Layers: packit.Layers{Path: layersDir},
 				})
-				Expect(err).To(MatchError(`"invalid-format" is not a supported SBOM format`))
+				Expect(err).To(MatchError(`unsupported SBOM format: 'invalid-format'`))
 			})
 		})
 	})
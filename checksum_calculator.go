@@ -0,0 +1,64 @@
+package gobuild
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+//go:generate faux --interface ChecksumCalculator --output fakes/checksum_calculator.go
+
+// ChecksumCalculator computes a stable digest over a set of files or
+// directories, used to decide whether a previously built layer can be
+// reused.
+type ChecksumCalculator interface {
+	Sum(paths ...string) (string, error)
+}
+
+// FileChecksumCalculator hashes the contents of files and directories on
+// disk, walking directories recursively.
+type FileChecksumCalculator struct{}
+
+// NewChecksumCalculator creates an instance of a FileChecksumCalculator.
+func NewChecksumCalculator() FileChecksumCalculator {
+	return FileChecksumCalculator{}
+}
+
+func (c FileChecksumCalculator) Sum(paths ...string) (string, error) {
+	hash := sha256.New()
+
+	for _, path := range paths {
+		err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			if info.IsDir() {
+				return nil
+			}
+
+			fh, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer fh.Close()
+
+			if _, err := io.Copy(hash, fh); err != nil {
+				return err
+			}
+
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to calculate checksum: %w", err)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	gobuild "github.com/paketo-buildpacks/go-build"
+	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/chronos"
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+)
+
+func main() {
+	logger := scribe.NewEmitter(os.Stdout)
+
+	packit.Run(
+		gobuild.Detect(),
+		gobuild.Build(
+			gobuild.NewBuildConfigurationParser(),
+			gobuild.NewGoBuildProcess(pexec.NewExecutable("go"), logger),
+			gobuild.NewChecksumCalculator(),
+			gobuild.NewGoPathManager(),
+			chronos.NewClock(time.Now),
+			logger,
+			gobuild.NewSourceRemover(),
+			gobuild.NewSBOMGenerator("syft"),
+			gobuild.NewModuleSBOMGenerator(pexec.NewExecutable("go")),
+			gobuild.NewLiveReloadRunnerRegistry(),
+			gobuild.NewProvenanceGenerator(pexec.NewExecutable("go")),
+			gobuild.NewGoVersionResolver(pexec.NewExecutable("go")),
+		),
+	)
+}
@@ -0,0 +1,60 @@
+package gobuild
+
+// BuildConfiguration is the set of user-controllable knobs that influence how
+// the Go module in the application source is compiled.
+type BuildConfiguration struct {
+	// Targets is the structured, per-target build configuration. Every entry
+	// is built into its own binary.
+	Targets []TargetSpec
+
+	// ImportPath is the import path of the module being built, used to derive
+	// the GOPATH workspace layout.
+	ImportPath string
+}
+
+// TargetSpec is the build configuration for a single target binary, supplied
+// either via the [[go.targets]] table in buildpack.yml or synthesized from
+// the flat BP_GO_TARGETS/BP_GO_BUILD_FLAGS/BP_GO_BUILD_LDFLAGS environment
+// variables.
+type TargetSpec struct {
+	// Path is the Go import path (relative to the module root) that should be
+	// built into a binary.
+	Path string `toml:"path"`
+
+	// Flags is the list of arguments passed through to `go build` ahead of
+	// this target, e.g. "-trimpath".
+	Flags []string `toml:"flags"`
+
+	// LDFlags is passed through as the `-ldflags` value for this target.
+	LDFlags string `toml:"ldflags"`
+
+	// Tags is the list of build tags passed through as the `-tags` value for
+	// this target.
+	Tags []string `toml:"tags"`
+
+	// Env is the set of environment variables set for this target's build
+	// invocation, in addition to the inherited build environment.
+	Env map[string]string `toml:"env"`
+
+	// CGOEnabled overrides CGO_ENABLED for this target's build invocation. A
+	// nil value leaves CGO_ENABLED unset, inheriting the ambient environment.
+	CGOEnabled *bool `toml:"cgo_enabled"`
+
+	// OutputName overrides the binary's filename within targets/bin, which
+	// otherwise defaults to the base name of Path.
+	OutputName string `toml:"output_name"`
+
+	// Default marks this target's launch process as the default process for
+	// the app image; when live reload is enabled, its reload process is
+	// marked as the default instead.
+	// When no target sets Default, the first declared target is used instead.
+	Default bool `toml:"default"`
+}
+
+//go:generate faux --interface ConfigurationParser --output fakes/configuration_parser.go
+
+// ConfigurationParser parses the buildpack-specific configuration that
+// controls the Go build out of buildpack.yml and the build environment.
+type ConfigurationParser interface {
+	Parse(workingDir, buildpackVersion string) (BuildConfiguration, error)
+}
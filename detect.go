@@ -0,0 +1,35 @@
+package gobuild
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/paketo-buildpacks/packit/v2"
+)
+
+// Detect returns a packit.DetectFunc that succeeds when the working
+// directory contains a go.mod file, indicating a Go module to build.
+func Detect() packit.DetectFunc {
+	return func(context packit.DetectContext) (packit.DetectResult, error) {
+		_, err := os.Stat(filepath.Join(context.WorkingDir, "go.mod"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return packit.DetectResult{}, packit.Fail.WithMessage("no go.mod found in the working directory")
+			}
+
+			return packit.DetectResult{}, err
+		}
+
+		return packit.DetectResult{
+			Plan: packit.BuildPlan{
+				Provides: []packit.BuildPlanProvision{
+					{Name: "go-build"},
+				},
+				Requires: []packit.BuildPlanRequirement{
+					{Name: "go"},
+					{Name: "go-build"},
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,35 @@
+package fakes
+
+import (
+	"sync"
+
+	gobuild "github.com/paketo-buildpacks/go-build"
+)
+
+type ConfigurationParser struct {
+	ParseCall struct {
+		mutex     sync.Mutex
+		CallCount int
+		Receives  struct {
+			WorkingDir       string
+			BuildpackVersion string
+		}
+		Returns struct {
+			BuildConfiguration gobuild.BuildConfiguration
+			Err                error
+		}
+		Stub func(string, string) (gobuild.BuildConfiguration, error)
+	}
+}
+
+func (f *ConfigurationParser) Parse(param1 string, param2 string) (gobuild.BuildConfiguration, error) {
+	f.ParseCall.mutex.Lock()
+	defer f.ParseCall.mutex.Unlock()
+	f.ParseCall.CallCount++
+	f.ParseCall.Receives.WorkingDir = param1
+	f.ParseCall.Receives.BuildpackVersion = param2
+	if f.ParseCall.Stub != nil {
+		return f.ParseCall.Stub(param1, param2)
+	}
+	return f.ParseCall.Returns.BuildConfiguration, f.ParseCall.Returns.Err
+}
@@ -0,0 +1,25 @@
+package fakes
+
+import "sync"
+
+type GoVersionResolver struct {
+	ResolveCall struct {
+		mutex     sync.Mutex
+		CallCount int
+		Returns   struct {
+			String string
+			Error  error
+		}
+		Stub func() (string, error)
+	}
+}
+
+func (f *GoVersionResolver) Resolve() (string, error) {
+	f.ResolveCall.mutex.Lock()
+	defer f.ResolveCall.mutex.Unlock()
+	f.ResolveCall.CallCount++
+	if f.ResolveCall.Stub != nil {
+		return f.ResolveCall.Stub()
+	}
+	return f.ResolveCall.Returns.String, f.ResolveCall.Returns.Error
+}
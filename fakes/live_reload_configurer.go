@@ -0,0 +1,34 @@
+package fakes
+
+import "sync"
+
+type LiveReloadConfigurer struct {
+	ConfigureCall struct {
+		mutex     sync.Mutex
+		CallCount int
+		Receives  struct {
+			Stack      string
+			WorkingDir string
+			Binary     string
+		}
+		Returns struct {
+			Command string
+			Args    []string
+			Err     error
+		}
+		Stub func(string, string, string) (string, []string, error)
+	}
+}
+
+func (f *LiveReloadConfigurer) Configure(param1 string, param2 string, param3 string) (string, []string, error) {
+	f.ConfigureCall.mutex.Lock()
+	defer f.ConfigureCall.mutex.Unlock()
+	f.ConfigureCall.CallCount++
+	f.ConfigureCall.Receives.Stack = param1
+	f.ConfigureCall.Receives.WorkingDir = param2
+	f.ConfigureCall.Receives.Binary = param3
+	if f.ConfigureCall.Stub != nil {
+		return f.ConfigureCall.Stub(param1, param2, param3)
+	}
+	return f.ConfigureCall.Returns.Command, f.ConfigureCall.Returns.Args, f.ConfigureCall.Returns.Err
+}
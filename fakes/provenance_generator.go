@@ -0,0 +1,33 @@
+package fakes
+
+import (
+	"sync"
+
+	gobuild "github.com/paketo-buildpacks/go-build"
+)
+
+type ProvenanceGenerator struct {
+	GenerateCall struct {
+		mutex     sync.Mutex
+		CallCount int
+		Receives  struct {
+			Spec gobuild.ProvenanceSpec
+		}
+		Returns struct {
+			Bytes []byte
+			Error error
+		}
+		Stub func(gobuild.ProvenanceSpec) ([]byte, error)
+	}
+}
+
+func (f *ProvenanceGenerator) Generate(param1 gobuild.ProvenanceSpec) ([]byte, error) {
+	f.GenerateCall.mutex.Lock()
+	defer f.GenerateCall.mutex.Unlock()
+	f.GenerateCall.CallCount++
+	f.GenerateCall.Receives.Spec = param1
+	if f.GenerateCall.Stub != nil {
+		return f.GenerateCall.Stub(param1)
+	}
+	return f.GenerateCall.Returns.Bytes, f.GenerateCall.Returns.Error
+}
@@ -0,0 +1,48 @@
+package gobuild
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+)
+
+//go:generate faux --interface GoVersionResolver --output fakes/go_version_resolver.go
+
+// GoVersionResolver reports the version of the Go toolchain that will
+// perform the compile.
+type GoVersionResolver interface {
+	Resolve() (string, error)
+}
+
+// ExecutableGoVersionResolver resolves the Go toolchain version by invoking
+// `go version` on the executable that GoBuildProcess compiles with, rather
+// than reporting the version the buildpack binary itself was compiled with,
+// which may differ from the toolchain actually resolved at build time.
+type ExecutableGoVersionResolver struct {
+	executable pexec.Executable
+}
+
+// NewGoVersionResolver creates an instance of an ExecutableGoVersionResolver.
+func NewGoVersionResolver(executable pexec.Executable) ExecutableGoVersionResolver {
+	return ExecutableGoVersionResolver{
+		executable: executable,
+	}
+}
+
+func (r ExecutableGoVersionResolver) Resolve() (string, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := r.executable.Execute(pexec.Execution{
+		Args:   []string{"version"},
+		Env:    os.Environ(),
+		Stdout: buffer,
+		Stderr: buffer,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to determine go version: %w: %s", err, buffer.String())
+	}
+
+	return strings.TrimSpace(buffer.String()), nil
+}
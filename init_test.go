@@ -0,0 +1,17 @@
+package gobuild_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnit(t *testing.T) {
+	suite := spec.New("gobuild", spec.Report(report.Terminal{}))
+	suite("Build", testBuild)
+	suite("LiveReloadRunnerRegistry", testLiveReloadRunnerRegistry)
+	suite("ModuleSBOMGenerator", testModuleSBOMGenerator)
+	suite("ProvenanceGenerator", testProvenanceGenerator)
+	suite.Run(t)
+}
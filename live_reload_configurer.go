@@ -0,0 +1,220 @@
+package gobuild
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LiveReloadConfiguration is the set of user-controllable knobs that tune how
+// a live-reload runner watches the application source and restarts the
+// compiled binary when it changes.
+type LiveReloadConfiguration struct {
+	// Runner is the name of the live-reload runner to invoke, e.g. "watchexec"
+	// (the default), "air", "reflex", or "modd".
+	Runner string
+
+	// Watch is the list of additional paths the runner should watch for
+	// changes, alongside the application working directory and the compiled
+	// binary's own directory.
+	Watch []string
+
+	// Ignore is the list of glob patterns the runner should exclude from its
+	// watch set.
+	Ignore []string
+
+	// Debounce is the interval the runner should wait after a change before
+	// restarting the process, e.g. "500ms".
+	Debounce string
+
+	// Signal is the OS signal sent to the running process before it is
+	// restarted, e.g. "SIGTERM".
+	Signal string
+}
+
+// LiveReloadRunner describes a single live-reload tool that can be selected
+// via BP_LIVE_RELOAD_RUNNER.
+type LiveReloadRunner struct {
+	// Name is the value of BP_LIVE_RELOAD_RUNNER that selects this runner.
+	Name string
+
+	// UnsupportedStacks lists the stacks this runner cannot run on, e.g.
+	// because it requires a binary that the stack does not ship.
+	UnsupportedStacks []string
+
+	// Args builds the full argv, including the runner executable itself,
+	// used to wrap execution of binary.
+	Args func(workingDir, binary string, config LiveReloadConfiguration) []string
+}
+
+//go:generate faux --interface LiveReloadConfigurer --output fakes/live_reload_configurer.go
+
+// LiveReloadConfigurer resolves the live-reload runner selected via the
+// build environment and builds the command used to wrap a compiled binary
+// with it.
+type LiveReloadConfigurer interface {
+	// Configure returns the command and arguments used to wrap the execution
+	// of binary with the configured live-reload runner, or an error if the
+	// runner is unknown or cannot run on stack.
+	Configure(stack, workingDir, binary string) (command string, args []string, err error)
+}
+
+// LiveReloadRunnerRegistry is a LiveReloadConfigurer that selects amongst a
+// registered set of LiveReloadRunners, defaulting to watchexec when
+// BP_LIVE_RELOAD_RUNNER is unset.
+type LiveReloadRunnerRegistry struct {
+	runners map[string]LiveReloadRunner
+}
+
+// NewLiveReloadRunnerRegistry creates a LiveReloadRunnerRegistry seeded with
+// the runners this buildpack supports out of the box: watchexec, air,
+// reflex, and modd.
+func NewLiveReloadRunnerRegistry() *LiveReloadRunnerRegistry {
+	registry := &LiveReloadRunnerRegistry{runners: map[string]LiveReloadRunner{}}
+
+	registry.Register(LiveReloadRunner{
+		Name:              "watchexec",
+		UnsupportedStacks: []string{TinyStack},
+		Args: func(workingDir, binary string, config LiveReloadConfiguration) []string {
+			args := []string{"watchexec", "--restart"}
+			for _, watch := range append([]string{workingDir, filepath.Dir(binary)}, config.Watch...) {
+				args = append(args, "--watch", watch)
+			}
+			for _, ignore := range config.Ignore {
+				args = append(args, "--ignore", ignore)
+			}
+			if config.Debounce != "" {
+				args = append(args, "--debounce", config.Debounce)
+			}
+			if config.Signal != "" {
+				args = append(args, "--signal", config.Signal)
+			}
+			args = append(args, "--", binary)
+			return args
+		},
+	})
+
+	registry.Register(LiveReloadRunner{
+		Name: "air",
+		Args: func(workingDir, binary string, config LiveReloadConfiguration) []string {
+			args := []string{"air", "-build.bin", binary, "-build.cmd", fmt.Sprintf("go build -o %s", binary)}
+			for _, watch := range config.Watch {
+				args = append(args, "-build.include_dir", watch)
+			}
+			for _, ignore := range config.Ignore {
+				args = append(args, "-build.exclude_dir", ignore)
+			}
+			if config.Debounce != "" {
+				args = append(args, "-build.delay", config.Debounce)
+			}
+			return args
+		},
+	})
+
+	registry.Register(LiveReloadRunner{
+		Name:              "reflex",
+		UnsupportedStacks: []string{TinyStack},
+		Args: func(workingDir, binary string, config LiveReloadConfiguration) []string {
+			args := []string{"reflex", "--decoration=none"}
+			for _, watch := range append([]string{workingDir}, config.Watch...) {
+				args = append(args, "--regex", watch)
+			}
+			for _, ignore := range config.Ignore {
+				args = append(args, "--exclude", ignore)
+			}
+			if config.Debounce != "" {
+				args = append(args, "--debounce", config.Debounce)
+			}
+			args = append(args, "--", binary)
+			return args
+		},
+	})
+
+	registry.Register(LiveReloadRunner{
+		Name:              "modd",
+		UnsupportedStacks: []string{TinyStack},
+		Args: func(workingDir, binary string, config LiveReloadConfiguration) []string {
+			return []string{"modd", "--notify-cmd", binary}
+		},
+	})
+
+	return registry
+}
+
+// Register adds a runner to the registry, allowing other buildpacks to
+// extend the set of live-reload tools this buildpack can select between.
+func (r *LiveReloadRunnerRegistry) Register(runner LiveReloadRunner) {
+	r.runners[runner.Name] = runner
+}
+
+func (r *LiveReloadRunnerRegistry) Configure(stack, workingDir, binary string) (string, []string, error) {
+	config, err := parseLiveReloadConfiguration()
+	if err != nil {
+		return "", nil, err
+	}
+
+	runner, ok := r.runners[config.Runner]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown live reload runner %q", config.Runner)
+	}
+
+	for _, unsupported := range runner.UnsupportedStacks {
+		if unsupported == stack {
+			return "", nil, fmt.Errorf("cannot enable live reload on stack '%s': stack does not support %s", stack, runner.Name)
+		}
+	}
+
+	args := runner.Args(workingDir, binary, config)
+	return args[0], args[1:], nil
+}
+
+// parseLiveReloadConfiguration parses LiveReloadConfiguration from the build
+// environment variables that this buildpack supports:
+//
+//	BP_LIVE_RELOAD_RUNNER    the name of the live-reload runner to use
+//	BP_LIVE_RELOAD_WATCH     a colon-separated list of paths to watch
+//	BP_LIVE_RELOAD_IGNORE    a colon-separated list of glob patterns to exclude
+//	BP_LIVE_RELOAD_DEBOUNCE  the debounce interval passed through to the runner
+//	BP_LIVE_RELOAD_SIGNAL    the signal sent to the process before restarting
+func parseLiveReloadConfiguration() (LiveReloadConfiguration, error) {
+	config := LiveReloadConfiguration{Runner: "watchexec"}
+
+	if runner, ok := os.LookupEnv("BP_LIVE_RELOAD_RUNNER"); ok {
+		config.Runner = runner
+	}
+
+	if watch, ok := os.LookupEnv("BP_LIVE_RELOAD_WATCH"); ok {
+		config.Watch = splitLiveReloadList(watch)
+	}
+
+	if ignore, ok := os.LookupEnv("BP_LIVE_RELOAD_IGNORE"); ok {
+		config.Ignore = splitLiveReloadList(ignore)
+	}
+
+	if debounce, ok := os.LookupEnv("BP_LIVE_RELOAD_DEBOUNCE"); ok {
+		if _, err := time.ParseDuration(debounce); err != nil {
+			return LiveReloadConfiguration{}, fmt.Errorf("failed to parse BP_LIVE_RELOAD_DEBOUNCE value %q: %w", debounce, err)
+		}
+		config.Debounce = debounce
+	}
+
+	if signal, ok := os.LookupEnv("BP_LIVE_RELOAD_SIGNAL"); ok {
+		config.Signal = signal
+	}
+
+	return config, nil
+}
+
+func splitLiveReloadList(value string) []string {
+	var parsed []string
+	for _, entry := range strings.Split(value, ":") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parsed = append(parsed, entry)
+	}
+	return parsed
+}
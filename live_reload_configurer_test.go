@@ -0,0 +1,199 @@
+package gobuild_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gobuild "github.com/paketo-buildpacks/go-build"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testLiveReloadRunnerRegistry(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		registry *gobuild.LiveReloadRunnerRegistry
+	)
+
+	it.Before(func() {
+		registry = gobuild.NewLiveReloadRunnerRegistry()
+	})
+
+	it.After(func() {
+		for _, name := range []string{"BP_LIVE_RELOAD_RUNNER", "BP_LIVE_RELOAD_WATCH", "BP_LIVE_RELOAD_IGNORE", "BP_LIVE_RELOAD_DEBOUNCE", "BP_LIVE_RELOAD_SIGNAL"} {
+			Expect(os.Unsetenv(name)).To(Succeed())
+		}
+	})
+
+	context("when BP_LIVE_RELOAD_RUNNER is not set", func() {
+		it("defaults to wrapping the binary with watchexec", func() {
+			command, args, err := registry.Configure("some-stack", "some-working-dir", filepath.Join("some-bin-dir", "some-binary"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command).To(Equal("watchexec"))
+			Expect(args).To(Equal([]string{
+				"--restart",
+				"--watch", "some-working-dir",
+				"--watch", "some-bin-dir",
+				"--", filepath.Join("some-bin-dir", "some-binary"),
+			}))
+		})
+	})
+
+	context("when BP_LIVE_RELOAD_RUNNER=air", func() {
+		it.Before(func() {
+			Expect(os.Setenv("BP_LIVE_RELOAD_RUNNER", "air")).To(Succeed())
+		})
+
+		it("wraps the binary with air", func() {
+			command, args, err := registry.Configure("some-stack", "some-working-dir", "some-binary")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command).To(Equal("air"))
+			Expect(args).To(Equal([]string{
+				"-build.bin", "some-binary",
+				"-build.cmd", "go build -o some-binary",
+			}))
+		})
+	})
+
+	context("when BP_LIVE_RELOAD_RUNNER=reflex", func() {
+		it.Before(func() {
+			Expect(os.Setenv("BP_LIVE_RELOAD_RUNNER", "reflex")).To(Succeed())
+		})
+
+		it("wraps the binary with reflex", func() {
+			command, args, err := registry.Configure("some-stack", "some-working-dir", "some-binary")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command).To(Equal("reflex"))
+			Expect(args).To(Equal([]string{
+				"--decoration=none",
+				"--regex", "some-working-dir",
+				"--", "some-binary",
+			}))
+		})
+
+		it("fails on the Tiny stack, which does not ship reflex", func() {
+			_, _, err := registry.Configure(gobuild.TinyStack, "some-working-dir", "some-binary")
+			Expect(err).To(MatchError(ContainSubstring("cannot enable live reload on stack 'io.paketo.stacks.tiny'")))
+		})
+	})
+
+	context("when BP_LIVE_RELOAD_RUNNER=modd", func() {
+		it.Before(func() {
+			Expect(os.Setenv("BP_LIVE_RELOAD_RUNNER", "modd")).To(Succeed())
+		})
+
+		it("wraps the binary with modd", func() {
+			command, args, err := registry.Configure("some-stack", "some-working-dir", "some-binary")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command).To(Equal("modd"))
+			Expect(args).To(Equal([]string{"--notify-cmd", "some-binary"}))
+		})
+	})
+
+	context("when BP_LIVE_RELOAD_WATCH, BP_LIVE_RELOAD_IGNORE, BP_LIVE_RELOAD_DEBOUNCE, and BP_LIVE_RELOAD_SIGNAL are set", func() {
+		it.Before(func() {
+			Expect(os.Setenv("BP_LIVE_RELOAD_WATCH", "some-dir:other-dir")).To(Succeed())
+			Expect(os.Setenv("BP_LIVE_RELOAD_IGNORE", "*.tmp:*.log")).To(Succeed())
+			Expect(os.Setenv("BP_LIVE_RELOAD_DEBOUNCE", "500ms")).To(Succeed())
+			Expect(os.Setenv("BP_LIVE_RELOAD_SIGNAL", "SIGTERM")).To(Succeed())
+		})
+
+		it("passes the watch globs, ignore globs, debounce, and signal through to watchexec", func() {
+			command, args, err := registry.Configure("some-stack", "some-working-dir", filepath.Join("some-bin-dir", "some-binary"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command).To(Equal("watchexec"))
+			Expect(args).To(Equal([]string{
+				"--restart",
+				"--watch", "some-working-dir",
+				"--watch", "some-bin-dir",
+				"--watch", "some-dir",
+				"--watch", "other-dir",
+				"--ignore", "*.tmp",
+				"--ignore", "*.log",
+				"--debounce", "500ms",
+				"--signal", "SIGTERM",
+				"--", filepath.Join("some-bin-dir", "some-binary"),
+			}))
+		})
+
+		it("passes the watch and ignore globs, and the debounce, through to air", func() {
+			Expect(os.Setenv("BP_LIVE_RELOAD_RUNNER", "air")).To(Succeed())
+
+			command, args, err := registry.Configure("some-stack", "some-working-dir", "some-binary")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command).To(Equal("air"))
+			Expect(args).To(Equal([]string{
+				"-build.bin", "some-binary",
+				"-build.cmd", "go build -o some-binary",
+				"-build.include_dir", "some-dir",
+				"-build.include_dir", "other-dir",
+				"-build.exclude_dir", "*.tmp",
+				"-build.exclude_dir", "*.log",
+				"-build.delay", "500ms",
+			}))
+		})
+
+		it("passes the watch and ignore globs, and the debounce, through to reflex", func() {
+			Expect(os.Setenv("BP_LIVE_RELOAD_RUNNER", "reflex")).To(Succeed())
+
+			command, args, err := registry.Configure("some-stack", "some-working-dir", "some-binary")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command).To(Equal("reflex"))
+			Expect(args).To(Equal([]string{
+				"--decoration=none",
+				"--regex", "some-working-dir",
+				"--regex", "some-dir",
+				"--regex", "other-dir",
+				"--exclude", "*.tmp",
+				"--exclude", "*.log",
+				"--debounce", "500ms",
+				"--", "some-binary",
+			}))
+		})
+	})
+
+	context("when BP_LIVE_RELOAD_DEBOUNCE cannot be parsed as a duration", func() {
+		it.Before(func() {
+			Expect(os.Setenv("BP_LIVE_RELOAD_DEBOUNCE", "not-a-duration")).To(Succeed())
+		})
+
+		it("returns an error", func() {
+			_, _, err := registry.Configure("some-stack", "some-working-dir", "some-binary")
+			Expect(err).To(MatchError(ContainSubstring(`failed to parse BP_LIVE_RELOAD_DEBOUNCE value "not-a-duration"`)))
+		})
+	})
+
+	context("when BP_LIVE_RELOAD_RUNNER names a runner that is not registered", func() {
+		it.Before(func() {
+			Expect(os.Setenv("BP_LIVE_RELOAD_RUNNER", "some-unknown-runner")).To(Succeed())
+		})
+
+		it("returns an error", func() {
+			_, _, err := registry.Configure("some-stack", "some-working-dir", "some-binary")
+			Expect(err).To(MatchError(`unknown live reload runner "some-unknown-runner"`))
+		})
+	})
+
+	context("when a custom runner has been registered", func() {
+		it.Before(func() {
+			registry.Register(gobuild.LiveReloadRunner{
+				Name: "custom-runner",
+				Args: func(workingDir, binary string, config gobuild.LiveReloadConfiguration) []string {
+					return []string{"custom-runner", "--watch-dir", workingDir, binary}
+				},
+			})
+
+			Expect(os.Setenv("BP_LIVE_RELOAD_RUNNER", "custom-runner")).To(Succeed())
+		})
+
+		it("wraps the binary with the custom runner", func() {
+			command, args, err := registry.Configure("some-stack", "some-working-dir", "some-binary")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command).To(Equal("custom-runner"))
+			Expect(args).To(Equal([]string{"--watch-dir", "some-working-dir", "some-binary"}))
+		})
+	})
+}
@@ -0,0 +1,208 @@
+package gobuild
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+	syftsbom "github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/sbom"
+)
+
+// goModule mirrors the subset of fields emitted by `go list -m -json` that
+// are needed to describe a resolved dependency.
+type goModule struct {
+	Path     string
+	Version  string
+	Main     bool
+	Indirect bool
+	Replace  *goModule
+}
+
+//go:generate faux --interface ModuleSBOMGenerator --output fakes/module_sbom_generator.go
+
+// ModuleSBOMGenerator produces an SBOM describing the Go modules resolved
+// for a workspace, so that transitive dependency data (module paths,
+// versions, and replace directives) can be included alongside the SBOM
+// generated from the compiled binaries.
+type ModuleSBOMGenerator interface {
+	Generate(workingDir string) (sbom.SBOM, error)
+}
+
+// GoListModuleSBOMGenerator resolves the Go module graph for a workspace by
+// invoking `go list -m -json all`, falling back to parsing
+// vendor/modules.txt, and then go.sum, when that command fails.
+type GoListModuleSBOMGenerator struct {
+	executable pexec.Executable
+}
+
+// NewModuleSBOMGenerator creates an instance of a GoListModuleSBOMGenerator.
+func NewModuleSBOMGenerator(executable pexec.Executable) GoListModuleSBOMGenerator {
+	return GoListModuleSBOMGenerator{
+		executable: executable,
+	}
+}
+
+func (g GoListModuleSBOMGenerator) Generate(workingDir string) (sbom.SBOM, error) {
+	modules, err := g.listModules(workingDir)
+	if err != nil {
+		modules, err = listVendoredModules(workingDir)
+		if err != nil {
+			modules, err = parseGoSum(filepath.Join(workingDir, "go.sum"))
+			if err != nil {
+				return sbom.SBOM{}, fmt.Errorf("failed to generate module SBOM: %w", err)
+			}
+		}
+	}
+
+	var packages []pkg.Package
+	for _, module := range modules {
+		if module.Main {
+			continue
+		}
+
+		name, version := module.Path, module.Version
+		if module.Replace != nil {
+			name, version = module.Replace.Path, module.Replace.Version
+		}
+
+		packages = append(packages, pkg.Package{
+			Name:     name,
+			Version:  version,
+			Type:     pkg.GoModulePkg,
+			PURL:     fmt.Sprintf("pkg:golang/%s@%s", name, version),
+			Language: pkg.Go,
+		})
+	}
+
+	return sbom.NewSBOM(syftsbom.SBOM{
+		Artifacts: syftsbom.Artifacts{
+			Packages: pkg.NewCatalog(packages...),
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   workingDir,
+		},
+	}), nil
+}
+
+func (g GoListModuleSBOMGenerator) listModules(workingDir string) ([]goModule, error) {
+	return listModules(g.executable, workingDir)
+}
+
+// listModules shells out to `go list -m -json all`, honoring any
+// GOFLAGS/GOPRIVATE settings present in the build environment, and decodes
+// the resulting stream of JSON objects. It is shared by
+// GoListModuleSBOMGenerator and SLSAProvenanceGenerator, which both resolve
+// the same module graph for their own purposes.
+func listModules(executable pexec.Executable, workingDir string) ([]goModule, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := executable.Execute(pexec.Execution{
+		Args:   []string{"list", "-m", "-json", "all"},
+		Dir:    workingDir,
+		Env:    os.Environ(),
+		Stdout: buffer,
+		Stderr: buffer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w: %s", err, buffer.String())
+	}
+
+	var modules []goModule
+	decoder := json.NewDecoder(buffer)
+	for decoder.More() {
+		var module goModule
+		if err := decoder.Decode(&module); err != nil {
+			return nil, fmt.Errorf("failed to parse module list: %w", err)
+		}
+		modules = append(modules, module)
+	}
+
+	return modules, nil
+}
+
+// listVendoredModules falls back to parsing vendor/modules.txt, the module
+// graph `go mod vendor` records alongside a vendored build, so that a build
+// running with -mod=vendor (and therefore without module cache or network
+// access for `go list -m -json all`) still reports the modules it vendored.
+func listVendoredModules(workingDir string) ([]goModule, error) {
+	file, err := os.Open(filepath.Join(workingDir, "vendor", "modules.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fall back to vendor/modules.txt: %w", err)
+	}
+	defer file.Close()
+
+	var modules []goModule
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[0] != "#" {
+			continue
+		}
+
+		module := goModule{Path: fields[1], Version: fields[2]}
+
+		if len(fields) >= 5 && fields[3] == "=>" {
+			replace := &goModule{Path: fields[4]}
+			if len(fields) >= 6 {
+				replace.Version = fields[5]
+			}
+			module.Replace = replace
+		}
+
+		modules = append(modules, module)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read vendor/modules.txt: %w", err)
+	}
+
+	return modules, nil
+}
+
+// parseGoSum falls back to deriving a (necessarily shallower) module list
+// from go.sum when the module graph cannot be resolved, e.g. because the
+// build is running offline.
+func parseGoSum(path string) ([]goModule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fall back to go.sum: %w", err)
+	}
+	defer file.Close()
+
+	seen := map[string]bool{}
+	var modules []goModule
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		path, version := fields[0], fields[1]
+		version = strings.TrimSuffix(version, "/go.mod")
+
+		key := fmt.Sprintf("%s@%s", path, version)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		modules = append(modules, goModule{Path: path, Version: version})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read go.sum: %w", err)
+	}
+
+	return modules, nil
+}
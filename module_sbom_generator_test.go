@@ -0,0 +1,100 @@
+package gobuild_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gobuild "github.com/paketo-buildpacks/go-build"
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/sbom"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testModuleSBOMGenerator(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		workingDir string
+	)
+
+	it.Before(func() {
+		var err error
+		workingDir, err = os.MkdirTemp("", "working-dir")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.WriteFile(filepath.Join(workingDir, "go.mod"), []byte("module some-module\n\ngo 1.20\n"), 0600)).To(Succeed())
+	})
+
+	it.After(func() {
+		Expect(os.RemoveAll(workingDir)).To(Succeed())
+	})
+
+	renderFormat := func(artifact sbom.SBOM) string {
+		formatter, err := artifact.InFormats(sbom.CycloneDXFormat)
+		Expect(err).NotTo(HaveOccurred())
+
+		content, err := io.ReadAll(formatter.Formats()[0].Content)
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(content)
+	}
+
+	context("when `go list -m -json all` succeeds", func() {
+		it("reports the modules it resolved", func() {
+			generator := gobuild.NewModuleSBOMGenerator(pexec.NewExecutable("go"))
+
+			artifact, err := generator.Generate(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			// the fixture module has no dependencies, so the main module itself
+			// (which Generate excludes) is all that `go list` resolves
+			Expect(renderFormat(artifact)).To(ContainSubstring(`"components": []`))
+		})
+	})
+
+	context("when `go list -m -json all` fails", func() {
+		it("falls back to parsing vendor/modules.txt", func() {
+			Expect(os.MkdirAll(filepath.Join(workingDir, "vendor"), os.ModePerm)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(workingDir, "vendor", "modules.txt"), []byte(
+				"# example.com/vendored-dep v1.2.3\n## explicit\nexample.com/vendored-dep\n",
+			), 0600)).To(Succeed())
+
+			generator := gobuild.NewModuleSBOMGenerator(pexec.NewExecutable("go-build-missing-binary"))
+
+			artifact, err := generator.Generate(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(renderFormat(artifact)).To(ContainSubstring("example.com/vendored-dep"))
+			Expect(renderFormat(artifact)).To(ContainSubstring("v1.2.3"))
+		})
+
+		context("and there is no vendor/modules.txt", func() {
+			it("falls back to parsing go.sum", func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, "go.sum"), []byte(
+					"example.com/summed-dep v4.5.6 h1:abc=\nexample.com/summed-dep v4.5.6/go.mod h1:def=\n",
+				), 0600)).To(Succeed())
+
+				generator := gobuild.NewModuleSBOMGenerator(pexec.NewExecutable("go-build-missing-binary"))
+
+				artifact, err := generator.Generate(workingDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(renderFormat(artifact)).To(ContainSubstring("example.com/summed-dep"))
+				Expect(renderFormat(artifact)).To(ContainSubstring("v4.5.6"))
+			})
+		})
+
+		context("and there is neither a vendor/modules.txt nor a go.sum", func() {
+			it("returns an error", func() {
+				generator := gobuild.NewModuleSBOMGenerator(pexec.NewExecutable("go-build-missing-binary"))
+
+				_, err := generator.Generate(workingDir)
+				Expect(err).To(MatchError(ContainSubstring("failed to generate module SBOM")))
+			})
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package gobuild
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+//go:generate faux --interface PathManager --output fakes/path_manager.go
+
+// PathManager lays out a temporary GOPATH workspace for the application
+// source ahead of the build, and tears it down again afterwards.
+type PathManager interface {
+	Setup(workspace, importPath string) (path, goPath string, err error)
+	Teardown(goPath string) error
+}
+
+// GoPathManager symlinks the application workspace into a GOPATH-style
+// directory structure so that `go build` can resolve the module by its
+// import path even when GO111MODULE is unset.
+type GoPathManager struct{}
+
+// NewGoPathManager creates an instance of a GoPathManager.
+func NewGoPathManager() GoPathManager {
+	return GoPathManager{}
+}
+
+func (m GoPathManager) Setup(workspace, importPath string) (string, string, error) {
+	goPath, err := os.MkdirTemp("", "go-path")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create GOPATH: %w", err)
+	}
+
+	path := filepath.Join(goPath, "src", importPath)
+
+	err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create GOPATH workspace: %w", err)
+	}
+
+	err = os.Symlink(workspace, path)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to symlink workspace into GOPATH: %w", err)
+	}
+
+	return path, goPath, nil
+}
+
+func (m GoPathManager) Teardown(goPath string) error {
+	err := os.RemoveAll(goPath)
+	if err != nil {
+		return fmt.Errorf("failed to teardown GOPATH: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package gobuild
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+)
+
+// ProvenanceSpec gathers the inputs to a single compile that a
+// ProvenanceGenerator needs in order to describe it in an in-toto
+// attestation: the identity of the builder, where the source lives, how it
+// was configured, and the binaries that were produced.
+type ProvenanceSpec struct {
+	// BuilderID identifies the buildpack that performed the compile, e.g.
+	// "Go Build Buildpack@1.2.3".
+	BuilderID string
+
+	// WorkingDir is the application source directory the build was run
+	// against, used to locate go.mod, go.sum, and the resolved module graph.
+	WorkingDir string
+
+	// Config is the resolved GoBuildConfiguration the compile was invoked
+	// with. When previously built binaries are reused, no compile runs and
+	// its Workspace and GoPath are empty.
+	Config GoBuildConfiguration
+
+	// Binaries is the list of binaries produced by the compile, whose
+	// contents are hashed into the statement's subjects.
+	Binaries []string
+}
+
+// ProvenanceStatement is an in-toto attestation statement carrying a SLSA
+// v1.0 build provenance predicate.
+type ProvenanceStatement struct {
+	Type          string              `json:"_type"`
+	Subject       []ProvenanceSubject `json:"subject"`
+	PredicateType string              `json:"predicateType"`
+	Predicate     ProvenancePredicate `json:"predicate"`
+}
+
+// ProvenanceSubject identifies one of the artifacts a ProvenanceStatement
+// describes, by its SHA-256 digest.
+type ProvenanceSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// ProvenancePredicate is the SLSA v1.0 provenance predicate: what built the
+// subjects, with what inputs, and from what materials.
+type ProvenancePredicate struct {
+	BuildDefinition ProvenanceBuildDefinition `json:"buildDefinition"`
+	RunDetails      ProvenanceRunDetails      `json:"runDetails"`
+}
+
+// ProvenanceBuildDefinition describes the inputs that produced the
+// statement's subjects.
+type ProvenanceBuildDefinition struct {
+	BuildType            string                 `json:"buildType"`
+	ExternalParameters   map[string]interface{} `json:"externalParameters"`
+	ResolvedDependencies []ProvenanceMaterial   `json:"resolvedDependencies"`
+}
+
+// ProvenanceMaterial is a single resolved dependency, identified by a URI
+// and, where one is available, a digest.
+type ProvenanceMaterial struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// ProvenanceRunDetails identifies the builder that carried out the build.
+type ProvenanceRunDetails struct {
+	Builder ProvenanceBuilder `json:"builder"`
+}
+
+// ProvenanceBuilder identifies the entity that ran the build.
+type ProvenanceBuilder struct {
+	ID string `json:"id"`
+}
+
+//go:generate faux --interface ProvenanceGenerator --output fakes/provenance_generator.go
+
+// ProvenanceGenerator produces an in-toto SLSA provenance statement
+// describing a single invocation of the Go build process.
+type ProvenanceGenerator interface {
+	Generate(spec ProvenanceSpec) ([]byte, error)
+}
+
+// provenanceEnvAllowlist is the set of build environment variables that are
+// safe to record verbatim as invocation parameters in a provenance
+// statement. Any other environment variable present in the build
+// environment, such as credentials injected via a service binding, is
+// omitted rather than redacted in place, so that secret-looking values are
+// never read into the generated provenance.json at all.
+var provenanceEnvAllowlist = map[string]bool{
+	"BP_GO_TARGETS":          true,
+	"BP_GO_BUILD_FLAGS":      true,
+	"BP_GO_BUILD_LDFLAGS":    true,
+	"BP_GO_BUILD_PROVENANCE": true,
+	"BP_LIVE_RELOAD_ENABLED": true,
+	"BP_LIVE_RELOAD_RUNNER":  true,
+	"CGO_ENABLED":            true,
+	"GOOS":                   true,
+	"GOARCH":                 true,
+	"GOFLAGS":                true,
+}
+
+// SLSAProvenanceGenerator builds a SLSA v1.0 provenance predicate from the
+// resolved build configuration, the module graph resolved by `go list -m
+// -json all`, and the binaries produced by the compile.
+type SLSAProvenanceGenerator struct {
+	executable pexec.Executable
+}
+
+// NewProvenanceGenerator creates an instance of a SLSAProvenanceGenerator.
+func NewProvenanceGenerator(executable pexec.Executable) SLSAProvenanceGenerator {
+	return SLSAProvenanceGenerator{
+		executable: executable,
+	}
+}
+
+func (g SLSAProvenanceGenerator) Generate(spec ProvenanceSpec) ([]byte, error) {
+	materials, err := g.materials(spec.WorkingDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve provenance materials: %w", err)
+	}
+
+	subjects := make([]ProvenanceSubject, len(spec.Binaries))
+	for i, binary := range spec.Binaries {
+		digest, err := sha256File(binary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash provenance subject: %w", err)
+		}
+
+		subjects[i] = ProvenanceSubject{
+			Name:   filepath.Base(binary),
+			Digest: map[string]string{"sha256": digest},
+		}
+	}
+
+	statement := ProvenanceStatement{
+		Type:          "https://in-toto.io/Statement/v1",
+		Subject:       subjects,
+		PredicateType: "https://slsa.dev/provenance/v1",
+		Predicate: ProvenancePredicate{
+			BuildDefinition: ProvenanceBuildDefinition{
+				BuildType: "https://paketo.io/go-build/provenance/v1",
+				ExternalParameters: map[string]interface{}{
+					"config": spec.Config,
+					"env":    provenanceEnv(),
+				},
+				ResolvedDependencies: materials,
+			},
+			RunDetails: ProvenanceRunDetails{
+				Builder: ProvenanceBuilder{ID: spec.BuilderID},
+			},
+		},
+	}
+
+	payload, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal provenance statement: %w", err)
+	}
+
+	return payload, nil
+}
+
+// materials hashes go.mod and go.sum (when present) and resolves the module
+// graph, so that the provenance statement records exactly which inputs the
+// compile resolved against. Module resolution falls back to parsing
+// vendor/modules.txt, then go.sum, mirroring
+// GoListModuleSBOMGenerator.Generate, so that enabling
+// BP_GO_BUILD_PROVENANCE on a vendored or offline build doesn't fail the
+// build outright.
+func (g SLSAProvenanceGenerator) materials(workingDir string) ([]ProvenanceMaterial, error) {
+	var materials []ProvenanceMaterial
+
+	for _, name := range []string{"go.mod", "go.sum"} {
+		path := filepath.Join(workingDir, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		digest, err := sha256File(path)
+		if err != nil {
+			return nil, err
+		}
+
+		materials = append(materials, ProvenanceMaterial{
+			URI:    name,
+			Digest: map[string]string{"sha256": digest},
+		})
+	}
+
+	modules, err := g.listModules(workingDir)
+	if err != nil {
+		modules, err = listVendoredModules(workingDir)
+		if err != nil {
+			modules, err = parseGoSum(filepath.Join(workingDir, "go.sum"))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, module := range modules {
+		if module.Main {
+			continue
+		}
+
+		name, version := module.Path, module.Version
+		if module.Replace != nil {
+			name, version = module.Replace.Path, module.Replace.Version
+		}
+
+		materials = append(materials, ProvenanceMaterial{
+			URI: fmt.Sprintf("pkg:golang/%s@%s", name, version),
+		})
+	}
+
+	return materials, nil
+}
+
+// listModules resolves the module graph for the build, decoding the same
+// goModule shape that GoListModuleSBOMGenerator uses to build the module
+// SBOM; the shelling-out logic itself is shared with that generator.
+func (g SLSAProvenanceGenerator) listModules(workingDir string) ([]goModule, error) {
+	return listModules(g.executable, workingDir)
+}
+
+// provenanceEnv returns the subset of the build environment that appears in
+// provenanceEnvAllowlist, so that the provenance statement's invocation
+// parameters never carry values that were not explicitly vetted as safe to
+// disclose.
+func provenanceEnv() map[string]string {
+	env := map[string]string{}
+	for _, entry := range os.Environ() {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || !provenanceEnvAllowlist[key] {
+			continue
+		}
+		env[key] = value
+	}
+
+	return env
+}
+
+// provenanceDigest returns the hex-encoded SHA-256 digest of a generated
+// provenance statement, so that Build can reference provenance.json from
+// the targets layer's metadata without having to re-read it from disk.
+func provenanceDigest(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+func sha256File(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,158 @@
+package gobuild_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gobuild "github.com/paketo-buildpacks/go-build"
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testProvenanceGenerator(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		workingDir string
+		binaryPath string
+	)
+
+	it.Before(func() {
+		var err error
+		workingDir, err = os.MkdirTemp("", "working-dir")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.WriteFile(filepath.Join(workingDir, "go.mod"), []byte("module some-module\n\ngo 1.20\n"), 0600)).To(Succeed())
+
+		file, err := os.CreateTemp("", "some-binary")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+		binaryPath = file.Name()
+		Expect(os.WriteFile(binaryPath, []byte("some-binary-content"), 0600)).To(Succeed())
+	})
+
+	it.After(func() {
+		Expect(os.RemoveAll(workingDir)).To(Succeed())
+		Expect(os.RemoveAll(binaryPath)).To(Succeed())
+	})
+
+	context("Generate", func() {
+		it("produces a SLSA provenance statement describing the compile", func() {
+			generator := gobuild.NewProvenanceGenerator(pexec.NewExecutable("go"))
+
+			payload, err := generator.Generate(gobuild.ProvenanceSpec{
+				BuilderID:  "Some Buildpack@some-version",
+				WorkingDir: workingDir,
+				Binaries:   []string{binaryPath},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var statement gobuild.ProvenanceStatement
+			Expect(json.Unmarshal(payload, &statement)).To(Succeed())
+
+			Expect(statement.Type).To(Equal("https://in-toto.io/Statement/v1"))
+			Expect(statement.PredicateType).To(Equal("https://slsa.dev/provenance/v1"))
+			Expect(statement.Predicate.RunDetails.Builder.ID).To(Equal("Some Buildpack@some-version"))
+			Expect(statement.Predicate.BuildDefinition.BuildType).To(Equal("https://paketo.io/go-build/provenance/v1"))
+
+			Expect(statement.Subject).To(HaveLen(1))
+			Expect(statement.Subject[0].Name).To(Equal(filepath.Base(binaryPath)))
+			Expect(statement.Subject[0].Digest).To(HaveKey("sha256"))
+
+			var materialURIs []string
+			for _, material := range statement.Predicate.BuildDefinition.ResolvedDependencies {
+				materialURIs = append(materialURIs, material.URI)
+			}
+			Expect(materialURIs).To(ContainElement("go.mod"))
+		})
+
+		context("when the build environment carries variables outside the allowlist", func() {
+			it.Before(func() {
+				Expect(os.Setenv("GOOS", "linux")).To(Succeed())
+				Expect(os.Setenv("SOME_SERVICE_BINDING_SECRET", "super-secret-value")).To(Succeed())
+			})
+
+			it.After(func() {
+				Expect(os.Unsetenv("GOOS")).To(Succeed())
+				Expect(os.Unsetenv("SOME_SERVICE_BINDING_SECRET")).To(Succeed())
+			})
+
+			it("records only the allowlisted variables, omitting anything else", func() {
+				generator := gobuild.NewProvenanceGenerator(pexec.NewExecutable("go"))
+
+				payload, err := generator.Generate(gobuild.ProvenanceSpec{
+					BuilderID:  "Some Buildpack@some-version",
+					WorkingDir: workingDir,
+					Binaries:   []string{binaryPath},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				var statement gobuild.ProvenanceStatement
+				Expect(json.Unmarshal(payload, &statement)).To(Succeed())
+
+				env, ok := statement.Predicate.BuildDefinition.ExternalParameters["env"].(map[string]interface{})
+				Expect(ok).To(BeTrue())
+
+				Expect(env).To(HaveKeyWithValue("GOOS", "linux"))
+				Expect(env).NotTo(HaveKey("SOME_SERVICE_BINDING_SECRET"))
+			})
+		})
+
+		context("when `go list -m -json all` fails", func() {
+			it("falls back to parsing vendor/modules.txt", func() {
+				Expect(os.MkdirAll(filepath.Join(workingDir, "vendor"), os.ModePerm)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(workingDir, "vendor", "modules.txt"), []byte(
+					"# example.com/vendored-dep v1.2.3\n## explicit\nexample.com/vendored-dep\n",
+				), 0600)).To(Succeed())
+
+				generator := gobuild.NewProvenanceGenerator(pexec.NewExecutable("go-build-missing-binary"))
+
+				payload, err := generator.Generate(gobuild.ProvenanceSpec{
+					BuilderID:  "Some Buildpack@some-version",
+					WorkingDir: workingDir,
+					Binaries:   []string{binaryPath},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				var statement gobuild.ProvenanceStatement
+				Expect(json.Unmarshal(payload, &statement)).To(Succeed())
+
+				var materialURIs []string
+				for _, material := range statement.Predicate.BuildDefinition.ResolvedDependencies {
+					materialURIs = append(materialURIs, material.URI)
+				}
+				Expect(materialURIs).To(ContainElement("pkg:golang/example.com/vendored-dep@v1.2.3"))
+			})
+
+			context("and there is neither a vendor/modules.txt nor a go.sum", func() {
+				it("returns an error", func() {
+					generator := gobuild.NewProvenanceGenerator(pexec.NewExecutable("go-build-missing-binary"))
+
+					_, err := generator.Generate(gobuild.ProvenanceSpec{
+						BuilderID:  "Some Buildpack@some-version",
+						WorkingDir: workingDir,
+						Binaries:   []string{binaryPath},
+					})
+					Expect(err).To(MatchError(ContainSubstring("failed to resolve provenance materials")))
+				})
+			})
+		})
+
+		context("when a binary cannot be hashed", func() {
+			it("returns an error", func() {
+				generator := gobuild.NewProvenanceGenerator(pexec.NewExecutable("go"))
+
+				_, err := generator.Generate(gobuild.ProvenanceSpec{
+					BuilderID:  "Some Buildpack@some-version",
+					WorkingDir: workingDir,
+					Binaries:   []string{filepath.Join(workingDir, "missing-binary")},
+				})
+				Expect(err).To(MatchError(ContainSubstring("failed to hash provenance subject")))
+			})
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package gobuild
+
+import (
+	"github.com/paketo-buildpacks/packit/v2/sbom"
+)
+
+//go:generate faux --interface SBOMGenerator --output fakes/sbom_generator.go
+
+// SBOMGenerator produces a Software Bill of Materials describing the
+// binaries produced by a build.
+type SBOMGenerator interface {
+	Generate(dir string) (sbom.SBOM, error)
+}
+
+// SyftSBOMGenerator generates an SBOM for the compiled binaries in a
+// directory by running syft in-process through packit's sbom.Generate; it
+// does not invoke the executable at executablePath.
+type SyftSBOMGenerator struct {
+	executablePath string
+}
+
+// NewSBOMGenerator creates an instance of a SyftSBOMGenerator.
+func NewSBOMGenerator(executablePath string) SyftSBOMGenerator {
+	return SyftSBOMGenerator{
+		executablePath: executablePath,
+	}
+}
+
+func (g SyftSBOMGenerator) Generate(dir string) (sbom.SBOM, error) {
+	return sbom.Generate(dir)
+}
@@ -0,0 +1,41 @@
+package gobuild
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+//go:generate faux --interface SourceRemover --output fakes/source_remover.go
+
+// SourceRemover removes the application source code from the working
+// directory once it has been compiled, so that it does not end up in the
+// final image.
+type SourceRemover interface {
+	Clear(path string) error
+}
+
+// AppSourceRemover deletes the contents of a working directory, preserving
+// the directory itself.
+type AppSourceRemover struct{}
+
+// NewSourceRemover creates an instance of an AppSourceRemover.
+func NewSourceRemover() AppSourceRemover {
+	return AppSourceRemover{}
+}
+
+func (r AppSourceRemover) Clear(path string) error {
+	files, err := os.ReadDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to list source contents: %w", err)
+	}
+
+	for _, file := range files {
+		err = os.RemoveAll(filepath.Join(path, file.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to remove source: %w", err)
+		}
+	}
+
+	return nil
+}